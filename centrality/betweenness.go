@@ -0,0 +1,66 @@
+package centrality
+
+import (
+	"github.com/danrl/golibby/graphalg"
+)
+
+// Betweenness returns the betweenness centrality of every node, i.e. the
+// fraction of shortest paths between pairs of other nodes that pass through the
+// node, using Brandes' algorithm. The values are normalized by the number of
+// pairs of other nodes, so that they range from zero to one.
+func Betweenness(g graphalg.Graph) map[string]float64 {
+	keys, adj := adjacency(g)
+	n := len(keys)
+	score := make([]float64, n)
+
+	// buffers reused across the single source shortest path runs
+	sigma := make([]float64, n)
+	dist := make([]int, n)
+	delta := make([]float64, n)
+	pred := make([][]int, n)
+	for s := 0; s < n; s++ {
+		for i := 0; i < n; i++ {
+			sigma[i] = 0
+			dist[i] = -1
+			delta[i] = 0
+			pred[i] = pred[i][:0]
+		}
+		sigma[s] = 1
+		dist[s] = 0
+		// stack lists the nodes in order of non-decreasing distance from s
+		stack := []int{s}
+		for head := 0; head < len(stack); head++ {
+			v := stack[head]
+			for _, w := range adj[v] {
+				if dist[w] < 0 {
+					dist[w] = dist[v] + 1
+					stack = append(stack, w)
+				}
+				if dist[w] == dist[v]+1 {
+					sigma[w] += sigma[v]
+					pred[w] = append(pred[w], v)
+				}
+			}
+		}
+		// accumulate dependencies in order of non-increasing distance
+		for i := len(stack) - 1; i > 0; i-- {
+			w := stack[i]
+			for _, v := range pred[w] {
+				delta[v] += sigma[v] / sigma[w] * (1 + delta[w])
+			}
+			score[w] += delta[w]
+		}
+	}
+
+	scale := 0.0
+	if n > 2 {
+		// both graph types are treated as directed, an undirected path is
+		// counted from both of its ends and so is every ordered pair
+		scale = 1 / float64((n-1)*(n-2))
+	}
+	centrality := make(map[string]float64, n)
+	for i, key := range keys {
+		centrality[key] = score[i] * scale
+	}
+	return centrality
+}
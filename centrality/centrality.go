@@ -0,0 +1,115 @@
+// Package centrality implements centrality measures that rank the nodes of
+// undirected and directed graphs by their importance.
+package centrality
+
+import (
+	"sort"
+
+	"github.com/danrl/golibby/graphalg"
+)
+
+// adjacency returns the node keys of a graph in lexical order and, for every
+// node, the indices of its neighbors in ascending order. Self-loops are
+// dropped, they do not contribute to any centrality measure.
+func adjacency(g graphalg.Graph) ([]string, [][]int) {
+	keys := g.Nodes()
+	sort.Strings(keys)
+	index := make(map[string]int, len(keys))
+	for i, key := range keys {
+		index[key] = i
+	}
+	adj := make([][]int, len(keys))
+	for i, key := range keys {
+		neighbors, _ := g.Neighbors(key)
+		for _, to := range neighbors {
+			// nodes added concurrently after the call to Nodes are ignored
+			if j, ok := index[to]; ok && j != i {
+				adj[i] = append(adj[i], j)
+			}
+		}
+	}
+	return keys, adj
+}
+
+// Degree returns the degree centrality of every node, i.e. the number of its
+// neighbors divided by the number of other nodes. For directed graphs the
+// out-degree is used.
+func Degree(g graphalg.Graph) map[string]float64 {
+	keys, adj := adjacency(g)
+	return degree(keys, adj)
+}
+
+// InDegree returns the in-degree centrality of every node of a directed graph,
+// i.e. the number of its predecessors divided by the number of other nodes
+func InDegree(g graphalg.Digraph) map[string]float64 {
+	keys, adj := adjacency(g)
+	in := make([][]int, len(keys))
+	for from, tos := range adj {
+		for _, to := range tos {
+			in[to] = append(in[to], from)
+		}
+	}
+	return degree(keys, in)
+}
+
+// degree implements Degree and InDegree on an adjacency list
+func degree(keys []string, adj [][]int) map[string]float64 {
+	centrality := make(map[string]float64, len(keys))
+	for i, key := range keys {
+		if len(keys) > 1 {
+			centrality[key] = float64(len(adj[i])) / float64(len(keys)-1)
+		} else {
+			centrality[key] = 0
+		}
+	}
+	return centrality
+}
+
+// distances returns the number of edges on a shortest path from the node with
+// index source to every other node, or -1 for unreachable nodes
+func distances(adj [][]int, source int) []int {
+	dist := make([]int, len(adj))
+	for i := range dist {
+		dist[i] = -1
+	}
+	dist[source] = 0
+	queue := []int{source}
+	for len(queue) > 0 {
+		v := queue[0]
+		queue = queue[1:]
+		for _, w := range adj[v] {
+			if dist[w] < 0 {
+				dist[w] = dist[v] + 1
+				queue = append(queue, w)
+			}
+		}
+	}
+	return dist
+}
+
+// Closeness returns the closeness centrality of every node, i.e. the inverse
+// of the average distance to all nodes it can reach. To compare nodes of
+// different components, the value is scaled by the fraction of other nodes
+// that can be reached (Wasserman and Faust). For directed graphs, distances
+// along outgoing edges are used. Nodes that reach no other node have a
+// centrality of zero.
+func Closeness(g graphalg.Graph) map[string]float64 {
+	keys, adj := adjacency(g)
+	centrality := make(map[string]float64, len(keys))
+	for i, key := range keys {
+		sum, reached := 0, 0
+		for _, d := range distances(adj, i) {
+			if d > 0 {
+				sum += d
+				reached++
+			}
+		}
+		if sum == 0 {
+			centrality[key] = 0
+			continue
+		}
+		centrality[key] = float64(reached) / float64(sum) *
+			float64(reached) / float64(len(keys)-1)
+	}
+	return centrality
+}
@@ -0,0 +1,118 @@
+package centrality
+
+import (
+	"math"
+	"testing"
+
+	"github.com/danrl/golibby/directedgraph"
+	"github.com/danrl/golibby/graph"
+)
+
+// near reports whether two floats are equal up to rounding errors
+func near(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+// undirected returns an undirected graph with the given edges
+func undirected(keys []string, edges ...[2]string) *graph.Graph {
+	g := graph.New()
+	for _, key := range keys {
+		g.NewNode(key, nil)
+	}
+	for _, e := range edges {
+		g.NewEdge(e[0], e[1])
+	}
+	return g
+}
+
+// directed returns a directed graph with the given edges
+func directed(keys []string, edges ...[2]string) *directedgraph.DirectedGraph {
+	g := directedgraph.New()
+	for _, key := range keys {
+		g.NewNode(key, nil)
+	}
+	for _, e := range edges {
+		g.NewEdge(e[0], e[1])
+	}
+	return g
+}
+
+// check compares centrality values against expected values
+func check(t *testing.T, expected, got map[string]float64) {
+	t.Helper()
+	if len(got) != len(expected) {
+		t.Errorf("expected `%v` got `%v`", expected, got)
+	}
+	for key, value := range expected {
+		if !near(got[key], value) {
+			t.Errorf("node `%v`: expected `%v` got `%v`", key, value, got[key])
+		}
+	}
+}
+
+func TestDegree(t *testing.T) {
+	t.Run("undirected graph", func(t *testing.T) {
+		g := undirected([]string{"a", "b", "c", "d", "e"},
+			[2]string{"a", "b"}, [2]string{"a", "c"}, [2]string{"a", "d"},
+			[2]string{"a", "e"}, [2]string{"e", "e"})
+		check(t, map[string]float64{"a": 1, "b": 0.25, "c": 0.25, "d": 0.25,
+			"e": 0.25}, Degree(g))
+	})
+	t.Run("directed graph", func(t *testing.T) {
+		g := directed([]string{"a", "b", "c"},
+			[2]string{"a", "b"}, [2]string{"a", "c"}, [2]string{"b", "c"})
+		check(t, map[string]float64{"a": 1, "b": 0.5, "c": 0}, Degree(g))
+		check(t, map[string]float64{"a": 0, "b": 0.5, "c": 1}, InDegree(g))
+	})
+	t.Run("single node", func(t *testing.T) {
+		check(t, map[string]float64{"a": 0}, Degree(undirected([]string{"a"})))
+	})
+}
+
+func TestCloseness(t *testing.T) {
+	t.Run("connected graph", func(t *testing.T) {
+		g := undirected([]string{"a", "b", "c"},
+			[2]string{"a", "b"}, [2]string{"b", "c"})
+		check(t, map[string]float64{"a": 2.0 / 3, "b": 1, "c": 2.0 / 3},
+			Closeness(g))
+	})
+	t.Run("disconnected graph", func(t *testing.T) {
+		g := undirected([]string{"a", "b", "c", "d"},
+			[2]string{"a", "b"}, [2]string{"b", "c"})
+		check(t, map[string]float64{"a": 4.0 / 9, "b": 2.0 / 3, "c": 4.0 / 9,
+			"d": 0}, Closeness(g))
+	})
+	t.Run("directed graph", func(t *testing.T) {
+		g := directed([]string{"a", "b", "c"},
+			[2]string{"a", "b"}, [2]string{"b", "c"})
+		check(t, map[string]float64{"a": 2.0 / 3, "b": 0.5, "c": 0},
+			Closeness(g))
+	})
+}
+
+func TestBetweenness(t *testing.T) {
+	t.Run("path", func(t *testing.T) {
+		g := undirected([]string{"a", "b", "c", "d"},
+			[2]string{"a", "b"}, [2]string{"b", "c"}, [2]string{"c", "d"})
+		check(t, map[string]float64{"a": 0, "b": 2.0 / 3, "c": 2.0 / 3, "d": 0},
+			Betweenness(g))
+	})
+	t.Run("parallel shortest paths", func(t *testing.T) {
+		// two shortest paths from a to d share the load
+		g := undirected([]string{"a", "b", "c", "d"},
+			[2]string{"a", "b"}, [2]string{"a", "c"}, [2]string{"b", "d"},
+			[2]string{"c", "d"})
+		check(t, map[string]float64{"a": 1.0 / 6, "b": 1.0 / 6, "c": 1.0 / 6,
+			"d": 1.0 / 6}, Betweenness(g))
+	})
+	t.Run("directed graph", func(t *testing.T) {
+		g := directed([]string{"a", "b", "c"},
+			[2]string{"a", "b"}, [2]string{"b", "c"})
+		check(t, map[string]float64{"a": 0, "b": 0.5, "c": 0}, Betweenness(g))
+	})
+	t.Run("small graphs", func(t *testing.T) {
+		g := undirected([]string{"a", "b"}, [2]string{"a", "b"})
+		check(t, map[string]float64{"a": 0, "b": 0}, Betweenness(g))
+		check(t, map[string]float64{}, Betweenness(graph.New()))
+	})
+}
@@ -0,0 +1,117 @@
+package centrality
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/danrl/golibby/graphalg"
+)
+
+var (
+	// ErrorInvalidDamping is returned when the damping factor is not within
+	// [0, 1]
+	ErrorInvalidDamping = fmt.Errorf("invalid damping factor")
+	// ErrorInvalidTolerance is returned when the tolerance is negative or NaN
+	ErrorInvalidTolerance = fmt.Errorf("invalid tolerance")
+	// ErrorNotConverged is returned when PageRank did not converge within the
+	// maximum number of iterations
+	ErrorNotConverged = fmt.Errorf("not converged")
+)
+
+const (
+	// DefaultDamping is the probability of following an edge, rather than
+	// jumping to a random node, used if PageRankOptions.Damping is nil
+	DefaultDamping = 0.85
+	// DefaultTolerance is the convergence tolerance per node used if
+	// PageRankOptions.Tolerance is zero
+	DefaultTolerance = 1e-6
+	// DefaultMaxIterations is the iteration cap used if
+	// PageRankOptions.MaxIterations is zero
+	DefaultMaxIterations = 100
+)
+
+// PageRankOptions configures PageRank. Zero values select the defaults.
+type PageRankOptions struct {
+	// Damping is the probability of following an outgoing edge, nil selects
+	// DefaultDamping
+	Damping *float64
+	// Tolerance stops the iteration once the ranks of all nodes changed by
+	// less than Tolerance per node in total
+	Tolerance float64
+	// MaxIterations is the maximum number of iterations
+	MaxIterations int
+}
+
+// PageRank returns the PageRank of every node of a directed graph, computed by
+// power iteration. The ranks sum up to one. The rank of dangling nodes, i.e.
+// nodes without outgoing edges, is distributed evenly across all nodes, as if
+// they were connected to every node. Self-loops are ignored.
+// ErrorNotConverged is returned along with the last ranks if the iteration cap
+// is reached.
+func PageRank(g graphalg.Digraph, opts PageRankOptions) (map[string]float64, error) {
+	damping := DefaultDamping
+	if opts.Damping != nil {
+		damping = *opts.Damping
+	}
+	// NaN fails both comparisons
+	if !(damping >= 0 && damping <= 1) {
+		return nil, ErrorInvalidDamping
+	}
+	tolerance := opts.Tolerance
+	if tolerance == 0 {
+		tolerance = DefaultTolerance
+	}
+	if !(tolerance > 0) {
+		return nil, ErrorInvalidTolerance
+	}
+	maxIterations := opts.MaxIterations
+	if maxIterations == 0 {
+		maxIterations = DefaultMaxIterations
+	}
+
+	keys, adj := adjacency(g)
+	n := len(keys)
+	ranks := make(map[string]float64, n)
+	if n == 0 {
+		return ranks, nil
+	}
+	rank := make([]float64, n)
+	next := make([]float64, n)
+	for i := range rank {
+		rank[i] = 1 / float64(n)
+	}
+	converged := false
+	for iteration := 0; iteration < maxIterations && !converged; iteration++ {
+		dangling := 0.0
+		for i := range adj {
+			if len(adj[i]) == 0 {
+				dangling += rank[i]
+			}
+		}
+		// teleportation and dangling rank are spread evenly
+		base := (1-damping)/float64(n) + damping*dangling/float64(n)
+		for i := range next {
+			next[i] = base
+		}
+		for i, tos := range adj {
+			share := damping * rank[i] / float64(len(tos))
+			for _, j := range tos {
+				next[j] += share
+			}
+		}
+		diff := 0.0
+		for i := range rank {
+			diff += math.Abs(next[i] - rank[i])
+		}
+		rank, next = next, rank
+		converged = diff < float64(n)*tolerance
+	}
+
+	for i, key := range keys {
+		ranks[key] = rank[i]
+	}
+	if !converged {
+		return ranks, ErrorNotConverged
+	}
+	return ranks, nil
+}
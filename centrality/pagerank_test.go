@@ -0,0 +1,96 @@
+package centrality
+
+import (
+	"math"
+	"testing"
+
+	"github.com/danrl/golibby/directedgraph"
+)
+
+func TestPageRank(t *testing.T) {
+	t.Run("empty graph", func(t *testing.T) {
+		got, err := PageRank(directedgraph.New(), PageRankOptions{})
+		if err != nil || len(got) != 0 {
+			t.Errorf("expected `%v` got `%v` `%v`", nil, got, err)
+		}
+	})
+	t.Run("cycle", func(t *testing.T) {
+		g := directed([]string{"a", "b", "c"},
+			[2]string{"a", "b"}, [2]string{"b", "c"}, [2]string{"c", "a"})
+		got, err := PageRank(g, PageRankOptions{})
+		if err != nil {
+			t.Errorf("expected `%v` got `%v`", nil, err)
+		}
+		check(t, map[string]float64{"a": 1.0 / 3, "b": 1.0 / 3, "c": 1.0 / 3}, got)
+	})
+	t.Run("dangling node", func(t *testing.T) {
+		// with b dangling, the ranks solve a = (1-d)/2 + d*b/2 and b = 1 - a,
+		// which gives a = 0.4 for d = 0.5
+		g := directed([]string{"a", "b"}, [2]string{"a", "b"})
+		damping := 0.5
+		got, err := PageRank(g, PageRankOptions{Damping: &damping, Tolerance: 1e-12})
+		if err != nil {
+			t.Errorf("expected `%v` got `%v`", nil, err)
+		}
+		check(t, map[string]float64{"a": 0.4, "b": 0.6}, got)
+	})
+	t.Run("ranking", func(t *testing.T) {
+		g := directed([]string{"api", "auth", "db", "cache", "web"},
+			[2]string{"web", "api"}, [2]string{"api", "auth"},
+			[2]string{"api", "db"}, [2]string{"api", "cache"},
+			[2]string{"auth", "db"}, [2]string{"cache", "db"})
+		got, err := PageRank(g, PageRankOptions{})
+		if err != nil {
+			t.Errorf("expected `%v` got `%v`", nil, err)
+		}
+		sum := 0.0
+		for _, rank := range got {
+			sum += rank
+		}
+		if !near(sum, 1) {
+			t.Errorf("expected ranks to sum up to `%v` got `%v`", 1, sum)
+		}
+		for _, key := range []string{"api", "auth", "cache", "web"} {
+			if got[key] >= got["db"] {
+				t.Errorf("expected `%v` to rank below `%v`", key, "db")
+			}
+		}
+	})
+	t.Run("not converged", func(t *testing.T) {
+		g := directed([]string{"a", "b", "c"},
+			[2]string{"a", "b"}, [2]string{"a", "c"})
+		got, err := PageRank(g, PageRankOptions{MaxIterations: 1})
+		if err != ErrorNotConverged {
+			t.Errorf("expected `%v` got `%v`", ErrorNotConverged, err)
+		}
+		if len(got) != 3 {
+			t.Errorf("expected ranks of `%v` nodes got `%v`", 3, got)
+		}
+	})
+	t.Run("zero damping", func(t *testing.T) {
+		g := directed([]string{"a", "b"}, [2]string{"a", "b"})
+		damping := 0.0
+		got, err := PageRank(g, PageRankOptions{Damping: &damping})
+		if err != nil {
+			t.Errorf("expected `%v` got `%v`", nil, err)
+		}
+		check(t, map[string]float64{"a": 0.5, "b": 0.5}, got)
+	})
+	t.Run("invalid damping", func(t *testing.T) {
+		for _, damping := range []float64{-0.5, 1.5, math.NaN()} {
+			damping := damping
+			_, err := PageRank(directedgraph.New(), PageRankOptions{Damping: &damping})
+			if err != ErrorInvalidDamping {
+				t.Errorf("expected `%v` got `%v`", ErrorInvalidDamping, err)
+			}
+		}
+	})
+	t.Run("invalid tolerance", func(t *testing.T) {
+		for _, tolerance := range []float64{-1, math.NaN()} {
+			_, err := PageRank(directedgraph.New(), PageRankOptions{Tolerance: tolerance})
+			if err != ErrorInvalidTolerance {
+				t.Errorf("expected `%v` got `%v`", ErrorInvalidTolerance, err)
+			}
+		}
+	})
+}
@@ -0,0 +1,156 @@
+// Package community detects communities, i.e. groups of densely connected
+// nodes, in undirected graphs. Communities are numbered from zero in the
+// lexical order of their smallest node key, so equal partitions always yield
+// equal assignments.
+package community
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/danrl/golibby/graphalg"
+)
+
+var (
+	// ErrorInvalidAssignment is returned when a community assignment does not
+	// cover every node of the graph
+	ErrorInvalidAssignment = fmt.Errorf("invalid assignment")
+	// ErrorInvalidResolution is returned when the resolution is negative
+	ErrorInvalidResolution = fmt.Errorf("invalid resolution")
+)
+
+// Graph is a read-only view of an undirected graph with weighted edges, as
+// implemented by graph.Graph. Edge weights are expected to be positive.
+type Graph interface {
+	graphalg.Graph
+	// Weight returns the weight of an edge
+	Weight(from, to string) (float64, error)
+}
+
+// network is a weighted adjacency list of an undirected graph. Self-loops are
+// kept apart from the adjacency lists, their weight counts twice towards the
+// degree of a node.
+type network struct {
+	adj     [][]int
+	weights [][]float64
+	loops   []float64
+	// degree is the weighted degree of every node
+	degree []float64
+	// total is the sum of all edge weights
+	total float64
+}
+
+// newNetwork returns the node keys of a graph in lexical order and its
+// weighted adjacency list, with neighbors in ascending order
+func newNetwork(g Graph) ([]string, *network) {
+	keys := g.Nodes()
+	sort.Strings(keys)
+	index := make(map[string]int, len(keys))
+	for i, key := range keys {
+		index[key] = i
+	}
+	n := &network{
+		adj:     make([][]int, len(keys)),
+		weights: make([][]float64, len(keys)),
+		loops:   make([]float64, len(keys)),
+		degree:  make([]float64, len(keys)),
+	}
+	for i, key := range keys {
+		neighbors, _ := g.Neighbors(key)
+		for _, to := range neighbors {
+			// nodes added concurrently after the call to Nodes are ignored
+			j, ok := index[to]
+			if !ok {
+				continue
+			}
+			weight, err := g.Weight(key, to)
+			if err != nil {
+				continue
+			}
+			if i == j {
+				n.loops[i] += weight
+				n.degree[i] += 2 * weight
+				n.total += weight
+				continue
+			}
+			n.adj[i] = append(n.adj[i], j)
+			n.weights[i] = append(n.weights[i], weight)
+			n.degree[i] += weight
+			if i < j {
+				n.total += weight
+			}
+		}
+	}
+	return keys, n
+}
+
+// modularity returns the modularity of a partition of the network, where
+// community holds the community of every node numbered from zero
+func (n *network) modularity(community []int, resolution float64) float64 {
+	if n.total == 0 {
+		return 0
+	}
+	internal := make([]float64, len(community))
+	degree := make([]float64, len(community))
+	for i := range n.adj {
+		c := community[i]
+		internal[c] += n.loops[i]
+		degree[c] += n.degree[i]
+		for k, j := range n.adj[i] {
+			if i < j && community[j] == c {
+				internal[c] += n.weights[i][k]
+			}
+		}
+	}
+	// summing up in community order keeps the result reproducible
+	q := 0.0
+	for c := range degree {
+		share := degree[c] / (2 * n.total)
+		q += internal[c]/n.total - resolution*share*share
+	}
+	return q
+}
+
+// normalize renumbers communities from zero in order of their first node
+func normalize(community []int) []int {
+	number := make(map[int]int)
+	normalized := make([]int, len(community))
+	for i, c := range community {
+		if _, ok := number[c]; !ok {
+			number[c] = len(number)
+		}
+		normalized[i] = number[c]
+	}
+	return normalized
+}
+
+// assignment maps the node keys to their communities
+func assignment(keys []string, community []int) map[string]int {
+	communities := make(map[string]int, len(keys))
+	for i, key := range keys {
+		communities[key] = community[i]
+	}
+	return communities
+}
+
+// Modularity returns the modularity of a partition of a graph into
+// communities. Modularity compares the weight of the edges within communities
+// to the weight expected if edges were placed at random while keeping the
+// degree of every node. Resolution values above one favor smaller
+// communities, values below one favor larger communities. Graphs without
+// edges have a modularity of zero.
+func Modularity(g Graph, communities map[string]int, resolution float64) (float64, error) {
+	if resolution < 0 {
+		return 0, ErrorInvalidResolution
+	}
+	keys, n := newNetwork(g)
+	community := make([]int, len(keys))
+	for i, key := range keys {
+		c, ok := communities[key]
+		if !ok {
+			return 0, ErrorInvalidAssignment
+		}
+		community[i] = c
+	}
+	return n.modularity(normalize(community), resolution), nil
+}
@@ -0,0 +1,110 @@
+package community
+
+import (
+	"math"
+	"testing"
+
+	"github.com/danrl/golibby/graph"
+)
+
+// barbell returns two triangles connected by a single edge
+func barbell() *graph.Graph {
+	g := graph.New()
+	for _, key := range []string{"a", "b", "c", "d", "e", "f"} {
+		g.NewNode(key, nil)
+	}
+	g.NewEdge("a", "b")
+	g.NewEdge("a", "c")
+	g.NewEdge("b", "c")
+	g.NewEdge("c", "d")
+	g.NewEdge("d", "e")
+	g.NewEdge("d", "f")
+	g.NewEdge("e", "f")
+	return g
+}
+
+func equal(a, b map[string]int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for key, c := range a {
+		if d, ok := b[key]; !ok || c != d {
+			return false
+		}
+	}
+	return true
+}
+
+func approx(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestModularity(t *testing.T) {
+	t.Run("partitions", func(t *testing.T) {
+		tt := []struct {
+			name        string
+			communities map[string]int
+			resolution  float64
+			expected    float64
+		}{
+			{
+				name:        "triangles",
+				communities: map[string]int{"a": 0, "b": 0, "c": 0, "d": 1, "e": 1, "f": 1},
+				resolution:  1,
+				expected:    6.0/7.0 - 0.5,
+			},
+			{
+				name:        "single community",
+				communities: map[string]int{"a": 0, "b": 0, "c": 0, "d": 0, "e": 0, "f": 0},
+				resolution:  1,
+				expected:    0,
+			},
+			{
+				name:        "zero resolution",
+				communities: map[string]int{"a": 0, "b": 0, "c": 0, "d": 1, "e": 1, "f": 1},
+				resolution:  0,
+				expected:    6.0 / 7.0,
+			},
+		}
+		for _, tc := range tt {
+			t.Run(tc.name, func(t *testing.T) {
+				got, err := Modularity(barbell(), tc.communities, tc.resolution)
+				if err != nil {
+					t.Errorf("expected `%v` got `%v`", nil, err)
+				}
+				if !approx(tc.expected, got) {
+					t.Errorf("expected `%v` got `%v`", tc.expected, got)
+				}
+			})
+		}
+	})
+	t.Run("self-loop", func(t *testing.T) {
+		g := graph.New()
+		g.NewNode("a", nil)
+		g.NewNode("b", nil)
+		g.NewEdge("a", "a")
+		g.NewEdge("a", "b")
+		// degrees are 3 and 1, the self-loop lies within the community of a
+		got, _ := Modularity(g, map[string]int{"a": 0, "b": 1}, 1)
+		expected := 0.5 - 9.0/16.0 - 1.0/16.0
+		if !approx(expected, got) {
+			t.Errorf("expected `%v` got `%v`", expected, got)
+		}
+	})
+	t.Run("empty graph", func(t *testing.T) {
+		got, err := Modularity(graph.New(), nil, 1)
+		if err != nil || got != 0 {
+			t.Errorf("expected `%v` got `%v`, `%v`", 0, got, err)
+		}
+	})
+	t.Run("invalid input", func(t *testing.T) {
+		_, err := Modularity(barbell(), map[string]int{"a": 0}, 1)
+		if err != ErrorInvalidAssignment {
+			t.Errorf("expected `%v` got `%v`", ErrorInvalidAssignment, err)
+		}
+		_, err = Modularity(barbell(), nil, -1)
+		if err != ErrorInvalidResolution {
+			t.Errorf("expected `%v` got `%v`", ErrorInvalidResolution, err)
+		}
+	})
+}
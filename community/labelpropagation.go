@@ -0,0 +1,73 @@
+package community
+
+import "math/rand"
+
+// maxRounds caps the number of rounds of label propagation. Propagation
+// rarely needs more than a handful of rounds, the cap only guards against
+// labels oscillating forever.
+const maxRounds = 100
+
+// LabelPropagation detects communities by label propagation as described by
+// Raghavan, Albert and Kumara. Every node starts with a label of its own and
+// then repeatedly adopts the label with the highest total edge weight among
+// its neighbors, until no node changes its label anymore. Nodes are visited in
+// random order and ties are broken randomly, the same seed always yields the
+// same result. It returns the community of every node and the modularity of
+// the partition.
+func LabelPropagation(g Graph, seed int64) (map[string]int, float64) {
+	keys, n := newNetwork(g)
+	rng := rand.New(rand.NewSource(seed))
+	label := make([]int, len(keys))
+	for i := range label {
+		label[i] = i
+	}
+
+	weight := make([]float64, len(keys))
+	listed := make([]bool, len(keys))
+	var candidates []int
+	for round := 0; round < maxRounds; round++ {
+		changed := false
+		for _, i := range rng.Perm(len(keys)) {
+			if len(n.adj[i]) == 0 {
+				continue
+			}
+			// labels are collected in order of appearance to keep the
+			// random choice among the best labels deterministic
+			var seen []int
+			for k, j := range n.adj[i] {
+				if !listed[label[j]] {
+					listed[label[j]] = true
+					seen = append(seen, label[j])
+				}
+				weight[label[j]] += n.weights[i][k]
+			}
+			best := weight[seen[0]]
+			for _, l := range seen[1:] {
+				if weight[l] > best {
+					best = weight[l]
+				}
+			}
+			candidates = candidates[:0]
+			keep := false
+			for _, l := range seen {
+				if weight[l] == best {
+					candidates = append(candidates, l)
+					keep = keep || l == label[i]
+				}
+				weight[l] = 0
+				listed[l] = false
+			}
+			if keep {
+				continue
+			}
+			label[i] = candidates[rng.Intn(len(candidates))]
+			changed = true
+		}
+		if !changed {
+			break
+		}
+	}
+
+	community := normalize(label)
+	return assignment(keys, community), n.modularity(community, 1)
+}
@@ -0,0 +1,46 @@
+package community
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/danrl/golibby/graph"
+	"github.com/danrl/golibby/graphgen"
+)
+
+func TestLabelPropagation(t *testing.T) {
+	t.Run("triangles", func(t *testing.T) {
+		for seed := int64(0); seed < 10; seed++ {
+			got, q := LabelPropagation(barbell(), seed)
+			expected := map[string]int{"a": 0, "b": 0, "c": 0, "d": 1, "e": 1, "f": 1}
+			if !equal(expected, got) {
+				t.Errorf("seed %v: expected `%v` got `%v`", seed, expected, got)
+			}
+			if !approx(6.0/7.0-0.5, q) {
+				t.Errorf("seed %v: expected `%v` got `%v`", seed, 6.0/7.0-0.5, q)
+			}
+		}
+	})
+	t.Run("isolated nodes", func(t *testing.T) {
+		g := graph.New()
+		g.NewNode("b", nil)
+		g.NewNode("a", nil)
+		got, q := LabelPropagation(g, 1)
+		expected := map[string]int{"a": 0, "b": 1}
+		if !equal(expected, got) || q != 0 {
+			t.Errorf("expected `%v` got `%v`, `%v`", expected, got, q)
+		}
+	})
+	t.Run("deterministic", func(t *testing.T) {
+		g, _ := graphgen.ErdosRenyi(200, 0.02, rand.New(rand.NewSource(1)))
+		first, q := LabelPropagation(g, 42)
+		second, r := LabelPropagation(g, 42)
+		if !equal(first, second) || q != r {
+			t.Errorf("expected equal results for equal seeds")
+		}
+		expected, _ := Modularity(g, first, 1)
+		if !approx(expected, q) {
+			t.Errorf("expected `%v` got `%v`", expected, q)
+		}
+	})
+}
@@ -0,0 +1,150 @@
+package community
+
+import "sort"
+
+// minGain is the smallest modularity gain, scaled by the total edge weight,
+// for which a node is moved. Smaller gains are rounding noise and could make
+// nodes move back and forth forever.
+const minGain = 1e-12
+
+// Louvain detects communities with the Louvain method by Blondel et al. Every
+// node starts in a community of its own. Nodes are then moved to the
+// neighboring community that increases modularity the most, until no move
+// increases it anymore. Communities are merged into single nodes and the
+// process repeats on the resulting graph until no node moves. Nodes are
+// visited in lexical order, so the result is deterministic. Resolution values
+// above one favor smaller communities, values below one favor larger
+// communities. It returns the community of every node and the modularity of
+// the partition at the given resolution.
+func Louvain(g Graph, resolution float64) (map[string]int, float64, error) {
+	if resolution < 0 {
+		return nil, 0, ErrorInvalidResolution
+	}
+	keys, n := newNetwork(g)
+	membership := make([]int, len(keys))
+	for i := range membership {
+		membership[i] = i
+	}
+	if n.total > 0 {
+		for level := n; ; {
+			community, moved := level.move(resolution)
+			if !moved {
+				break
+			}
+			community = normalize(community)
+			for i, c := range membership {
+				membership[i] = community[c]
+			}
+			level = level.aggregate(community)
+		}
+	}
+	membership = normalize(membership)
+	return assignment(keys, membership), n.modularity(membership, resolution), nil
+}
+
+// move repeatedly moves every node to the neighboring community that
+// increases modularity the most. It returns the community of every node and
+// whether any node has been moved at all.
+func (n *network) move(resolution float64) ([]int, bool) {
+	community := make([]int, len(n.adj))
+	// total holds the sum of the degrees of the nodes in every community
+	total := make([]float64, len(n.adj))
+	for i := range community {
+		community[i] = i
+		total[i] = n.degree[i]
+	}
+	// weight holds the weight of the edges from the current node to every
+	// community listed in seen
+	weight := make([]float64, len(n.adj))
+	listed := make([]bool, len(n.adj))
+	var seen []int
+	moved := false
+	for improved := true; improved; {
+		improved = false
+		for i := range n.adj {
+			seen = seen[:0]
+			for k, j := range n.adj[i] {
+				c := community[j]
+				if !listed[c] {
+					listed[c] = true
+					seen = append(seen, c)
+				}
+				weight[c] += n.weights[i][k]
+			}
+			// the gain of joining a community, scaled by the total edge
+			// weight, after removing the node from its community
+			current := community[i]
+			total[current] -= n.degree[i]
+			gain := func(c int) float64 {
+				return weight[c] - resolution*total[c]*n.degree[i]/(2*n.total)
+			}
+			best, bestGain := current, gain(current)
+			for _, c := range seen {
+				if g := gain(c); g > bestGain+minGain {
+					best, bestGain = c, g
+				}
+			}
+			for _, c := range seen {
+				weight[c] = 0
+				listed[c] = false
+			}
+			total[best] += n.degree[i]
+			if best != current {
+				community[i] = best
+				improved = true
+				moved = true
+			}
+		}
+	}
+	return community, moved
+}
+
+// aggregate returns a network with one node per community, where community
+// holds the community of every node numbered from zero. Edges within a
+// community become a self-loop.
+func (n *network) aggregate(community []int) *network {
+	size := 0
+	for _, c := range community {
+		if c >= size {
+			size = c + 1
+		}
+	}
+	a := &network{
+		adj:     make([][]int, size),
+		weights: make([][]float64, size),
+		loops:   make([]float64, size),
+		degree:  make([]float64, size),
+		total:   n.total,
+	}
+	links := make([]map[int]float64, size)
+	for i := range links {
+		links[i] = make(map[int]float64)
+	}
+	for i := range n.adj {
+		c := community[i]
+		a.loops[c] += n.loops[i]
+		a.degree[c] += n.degree[i]
+		for k, j := range n.adj[i] {
+			if i > j {
+				continue
+			}
+			d := community[j]
+			if c == d {
+				a.loops[c] += n.weights[i][k]
+				continue
+			}
+			links[c][d] += n.weights[i][k]
+			links[d][c] += n.weights[i][k]
+		}
+	}
+	for c := range links {
+		for d := range links[c] {
+			a.adj[c] = append(a.adj[c], d)
+		}
+		sort.Ints(a.adj[c])
+		for _, d := range a.adj[c] {
+			a.weights[c] = append(a.weights[c], links[c][d])
+		}
+	}
+	return a
+}
@@ -0,0 +1,120 @@
+package community
+
+import (
+	"math/rand"
+	"strconv"
+	"testing"
+
+	"github.com/danrl/golibby/graph"
+	"github.com/danrl/golibby/graphgen"
+)
+
+// cliques returns k cliques of size n connected in a ring by single edges
+func cliques(k, n int) *graph.Graph {
+	g := graph.New()
+	key := func(c, i int) string {
+		return strconv.Itoa(c) + "-" + strconv.Itoa(i)
+	}
+	for c := 0; c < k; c++ {
+		for i := 0; i < n; i++ {
+			g.NewNode(key(c, i), nil)
+			for j := 0; j < i; j++ {
+				g.NewEdge(key(c, j), key(c, i))
+			}
+		}
+	}
+	for c := 0; c < k; c++ {
+		g.NewEdge(key(c, 0), key((c+1)%k, n-1))
+	}
+	return g
+}
+
+func TestLouvain(t *testing.T) {
+	t.Run("triangles", func(t *testing.T) {
+		got, q, err := Louvain(barbell(), 1)
+		if err != nil {
+			t.Errorf("expected `%v` got `%v`", nil, err)
+		}
+		expected := map[string]int{"a": 0, "b": 0, "c": 0, "d": 1, "e": 1, "f": 1}
+		if !equal(expected, got) {
+			t.Errorf("expected `%v` got `%v`", expected, got)
+		}
+		if !approx(6.0/7.0-0.5, q) {
+			t.Errorf("expected `%v` got `%v`", 6.0/7.0-0.5, q)
+		}
+	})
+	t.Run("weights", func(t *testing.T) {
+		g := graph.New()
+		for _, key := range []string{"a", "b", "c", "d"} {
+			g.NewNode(key, nil)
+		}
+		g.NewEdgeWithWeight("a", "b", 10)
+		g.NewEdge("b", "c")
+		g.NewEdgeWithWeight("c", "d", 10)
+		g.NewEdge("d", "a")
+		got, _, _ := Louvain(g, 1)
+		expected := map[string]int{"a": 0, "b": 0, "c": 1, "d": 1}
+		if !equal(expected, got) {
+			t.Errorf("expected `%v` got `%v`", expected, got)
+		}
+		g.SetWeight("a", "b", 1)
+		g.SetWeight("c", "d", 1)
+		g.SetWeight("b", "c", 10)
+		g.SetWeight("d", "a", 10)
+		got, _, _ = Louvain(g, 1)
+		expected = map[string]int{"a": 0, "b": 1, "c": 1, "d": 0}
+		if !equal(expected, got) {
+			t.Errorf("expected `%v` got `%v`", expected, got)
+		}
+	})
+	t.Run("ring of cliques", func(t *testing.T) {
+		got, _, _ := Louvain(cliques(8, 5), 1)
+		for key, c := range got {
+			clique, _ := strconv.Atoi(key[:1])
+			if c != clique {
+				t.Errorf("node %v: expected `%v` got `%v`", key, clique, c)
+			}
+		}
+	})
+	t.Run("resolution", func(t *testing.T) {
+		g := cliques(8, 5)
+		_, fine, _ := Louvain(g, 1)
+		coarse, _, _ := Louvain(g, 0)
+		for key, c := range coarse {
+			if c != 0 {
+				t.Errorf("node %v: expected `%v` got `%v`", key, 0, c)
+			}
+		}
+		if fine <= 0 {
+			t.Errorf("expected positive modularity got `%v`", fine)
+		}
+	})
+	t.Run("empty graph", func(t *testing.T) {
+		got, q, err := Louvain(graph.New(), 1)
+		if err != nil || len(got) != 0 || q != 0 {
+			t.Errorf("expected empty result got `%v`, `%v`, `%v`", got, q, err)
+		}
+	})
+	t.Run("consistent modularity", func(t *testing.T) {
+		g, _ := graphgen.ErdosRenyi(300, 0.02, rand.New(rand.NewSource(7)))
+		for _, resolution := range []float64{0.5, 1, 2} {
+			got, q, _ := Louvain(g, resolution)
+			expected, _ := Modularity(g, got, resolution)
+			if !approx(expected, q) {
+				t.Errorf("expected `%v` got `%v`", expected, q)
+			}
+			singletons := make(map[string]int)
+			for i, key := range g.Nodes() {
+				singletons[key] = i
+			}
+			if baseline, _ := Modularity(g, singletons, resolution); q < baseline {
+				t.Errorf("expected at least `%v` got `%v`", baseline, q)
+			}
+		}
+	})
+	t.Run("invalid input", func(t *testing.T) {
+		if _, _, err := Louvain(barbell(), -1); err != ErrorInvalidResolution {
+			t.Errorf("expected `%v` got `%v`", ErrorInvalidResolution, err)
+		}
+	})
+}
@@ -0,0 +1,197 @@
+// Package csr implements immutable graphs in compressed sparse row format.
+// Nodes are identified by dense integer IDs, assigned in lexical order of
+// their keys, and the neighbors of all nodes are stored back to back in a
+// single slice. This takes a fraction of the memory of map based adjacency
+// and makes iterating over neighbors cheap. Graphs are safe for concurrent
+// use as they never change.
+package csr
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+var (
+	// ErrorNodeNotFound is returned when trying to access a non-existent node
+	ErrorNodeNotFound = fmt.Errorf("node not found")
+	// ErrorInvalidKeys is returned when the keys of a new graph are not
+	// unique, not in lexical order or more than math.MaxInt32
+	ErrorInvalidKeys = fmt.Errorf("invalid keys")
+	// ErrorInvalidID is returned when an adjacency list refers to a
+	// non-existent node
+	ErrorInvalidID = fmt.Errorf("invalid id")
+	// ErrorInconsistentAdjacency is returned when an adjacency function
+	// returns more IDs for a node on the second call than on the first one
+	ErrorInconsistentAdjacency = fmt.Errorf("inconsistent adjacency")
+)
+
+// table maps node keys to IDs and back and holds the values of the nodes. As
+// keys are sorted, the ID of a key is found by binary search.
+type table struct {
+	keys   []string
+	values []interface{}
+}
+
+// newTable validates keys and values of a new graph
+func newTable(keys []string, values []interface{}) (table, error) {
+	if len(keys) > math.MaxInt32 {
+		return table{}, ErrorInvalidKeys
+	}
+	for i := 1; i < len(keys); i++ {
+		if keys[i-1] >= keys[i] {
+			return table{}, ErrorInvalidKeys
+		}
+	}
+	if values == nil {
+		values = make([]interface{}, len(keys))
+	}
+	if len(values) != len(keys) {
+		return table{}, ErrorInvalidKeys
+	}
+	return table{keys: keys, values: values}, nil
+}
+
+// Len returns the number of nodes in the graph
+func (t *table) Len() int {
+	return len(t.keys)
+}
+
+// ID returns the ID of the node identified by key
+func (t *table) ID(key string) (int, bool) {
+	i := sort.SearchStrings(t.keys, key)
+	if i < len(t.keys) && t.keys[i] == key {
+		return i, true
+	}
+	return 0, false
+}
+
+// Key returns the key of the node with the given ID. It panics if the ID is
+// out of range.
+func (t *table) Key(id int) string {
+	return t.keys[id]
+}
+
+// Nodes returns a list of all nodes in the graph in lexical order
+func (t *table) Nodes() []string {
+	nodes := make([]string, len(t.keys))
+	copy(nodes, t.keys)
+	return nodes
+}
+
+// Value retrieves the value assigned to the node identified by key
+func (t *table) Value(key string) (interface{}, error) {
+	id, ok := t.ID(key)
+	if !ok {
+		return nil, ErrorNodeNotFound
+	}
+	return t.values[id], nil
+}
+
+// keysOf returns the keys of a list of node IDs
+func (t *table) keysOf(list []int32) []string {
+	keys := make([]string, len(list))
+	for i, id := range list {
+		keys[i] = t.keys[id]
+	}
+	return keys
+}
+
+// ids is a list of node IDs that can be sorted
+type ids []int32
+
+func (x ids) Len() int           { return len(x) }
+func (x ids) Less(i, j int) bool { return x[i] < x[j] }
+func (x ids) Swap(i, j int)      { x[i], x[j] = x[j], x[i] }
+
+// rows is an adjacency structure in compressed sparse row format. The
+// neighbors of node i are targets[offsets[i]:offsets[i+1]], in ascending
+// order. Targets take four bytes per edge.
+type rows struct {
+	offsets []int
+	targets []int32
+}
+
+// newRows builds the rows of n nodes from the adjacency function, which is
+// called twice per node in ID order: first to size the rows, then to fill
+// them. Targets are allocated once and filled in place.
+func newRows(n int, adjacency func(id int) []int) (rows, error) {
+	r := rows{offsets: make([]int, n+1)}
+	total := 0
+	for id := 0; id < n; id++ {
+		neighbors := adjacency(id)
+		for _, to := range neighbors {
+			if to < 0 || to >= n {
+				return rows{}, ErrorInvalidID
+			}
+		}
+		total += len(neighbors)
+	}
+	r.targets = make([]int32, total)
+	end := 0
+	for id := 0; id < n; id++ {
+		neighbors := adjacency(id)
+		if end+len(neighbors) > total {
+			return rows{}, ErrorInconsistentAdjacency
+		}
+		start := end
+		for _, to := range neighbors {
+			if to < 0 || to >= n {
+				return rows{}, ErrorInvalidID
+			}
+			r.targets[end] = int32(to)
+			end++
+		}
+		row := ids(r.targets[start:end])
+		sort.Sort(row)
+		// drop duplicates, the next row starts right after the unique IDs
+		unique := 0
+		for i := range row {
+			if i == 0 || row[i] != row[i-1] {
+				row[unique] = row[i]
+				unique++
+			}
+		}
+		end = start + unique
+		r.offsets[id+1] = end
+	}
+	r.targets = r.targets[:end]
+	return r, nil
+}
+
+// row returns the neighbor IDs of a node
+func (r *rows) row(id int) []int32 {
+	return r.targets[r.offsets[id]:r.offsets[id+1]:r.offsets[id+1]]
+}
+
+// has returns true if to is a neighbor of from
+func (r *rows) has(from, to int) bool {
+	row := r.row(from)
+	i := sort.Search(len(row), func(i int) bool { return int(row[i]) >= to })
+	return i < len(row) && int(row[i]) == to
+}
+
+// transpose returns the rows with all edges reversed. Rows are filled in
+// ascending order of their source, so they end up sorted.
+func (r *rows) transpose() rows {
+	n := len(r.offsets) - 1
+	t := rows{
+		offsets: make([]int, n+1),
+		targets: make([]int32, len(r.targets)),
+	}
+	for _, to := range r.targets {
+		t.offsets[to+1]++
+	}
+	for i := 0; i < n; i++ {
+		t.offsets[i+1] += t.offsets[i]
+	}
+	next := make([]int, n)
+	copy(next, t.offsets[:n])
+	for from := 0; from < n; from++ {
+		for _, to := range r.row(from) {
+			t.targets[next[to]] = int32(from)
+			next[to]++
+		}
+	}
+	return t
+}
@@ -0,0 +1,140 @@
+package csr
+
+import (
+	"testing"
+)
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func equalIDs(a []int, b []int32) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != int(b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// lists returns an adjacency function for fixed adjacency lists
+func lists(adj ...[]int) func(id int) []int {
+	return func(id int) []int {
+		return adj[id]
+	}
+}
+
+func TestNewTable(t *testing.T) {
+	tt := []struct {
+		name     string
+		keys     []string
+		values   []interface{}
+		expected error
+	}{
+		{name: "sorted keys", keys: []string{"a", "b"}, expected: nil},
+		{name: "with values", keys: []string{"a", "b"},
+			values: []interface{}{1, 2}, expected: nil},
+		{name: "unsorted keys", keys: []string{"b", "a"}, expected: ErrorInvalidKeys},
+		{name: "duplicate keys", keys: []string{"a", "a"}, expected: ErrorInvalidKeys},
+		{name: "missing values", keys: []string{"a", "b"},
+			values: []interface{}{1}, expected: ErrorInvalidKeys},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := newTable(tc.keys, tc.values); err != tc.expected {
+				t.Errorf("expected `%v` got `%v`", tc.expected, err)
+			}
+		})
+	}
+}
+
+func TestTable(t *testing.T) {
+	tb, _ := newTable([]string{"a", "c", "e"}, []interface{}{1, nil, "x"})
+	if got := tb.Len(); got != 3 {
+		t.Errorf("expected `%v` got `%v`", 3, got)
+	}
+	for id, key := range []string{"a", "c", "e"} {
+		got, ok := tb.ID(key)
+		if !ok || got != id {
+			t.Errorf("key `%v`: expected `%v` got `%v`", key, id, got)
+		}
+		if got := tb.Key(id); got != key {
+			t.Errorf("id `%v`: expected `%v` got `%v`", id, key, got)
+		}
+	}
+	for _, key := range []string{"", "b", "f"} {
+		if _, ok := tb.ID(key); ok {
+			t.Errorf("unexpected id for key `%v`", key)
+		}
+	}
+	if value, _ := tb.Value("e"); value != "x" {
+		t.Errorf("expected `%v` got `%v`", "x", value)
+	}
+	if _, err := tb.Value("b"); err != ErrorNodeNotFound {
+		t.Errorf("expected `%v` got `%v`", ErrorNodeNotFound, err)
+	}
+	nodes := tb.Nodes()
+	nodes[0] = "z"
+	if tb.Key(0) != "a" {
+		t.Errorf("expected copy of keys")
+	}
+}
+
+func TestRows(t *testing.T) {
+	t.Run("sorted unique rows", func(t *testing.T) {
+		r, err := newRows(4, lists([]int{2, 1, 2}, nil, []int{3, 0}, []int{3}))
+		if err != nil {
+			t.Fatalf("expected `%v` got `%v`", nil, err)
+		}
+		expected := [][]int{{1, 2}, {}, {0, 3}, {3}}
+		for id := range expected {
+			if got := r.row(id); !equalIDs(expected[id], got) {
+				t.Errorf("row `%v`: expected `%v` got `%v`", id, expected[id], got)
+			}
+		}
+		if !r.has(2, 3) || r.has(2, 1) || !r.has(3, 3) {
+			t.Errorf("unexpected edges in `%v`", r)
+		}
+	})
+	t.Run("transpose", func(t *testing.T) {
+		r, _ := newRows(4, lists([]int{1, 2}, nil, []int{0, 3}, []int{3}))
+		tr := r.transpose()
+		expected := [][]int{{2}, {0}, {0}, {2, 3}}
+		for id := range expected {
+			if got := tr.row(id); !equalIDs(expected[id], got) {
+				t.Errorf("row `%v`: expected `%v` got `%v`", id, expected[id], got)
+			}
+		}
+	})
+	t.Run("invalid ids", func(t *testing.T) {
+		for _, adj := range [][]int{{-1}, {1}} {
+			if _, err := newRows(1, lists(adj)); err != ErrorInvalidID {
+				t.Errorf("expected `%v` got `%v`", ErrorInvalidID, err)
+			}
+		}
+	})
+	t.Run("inconsistent adjacency", func(t *testing.T) {
+		calls := 0
+		adjacency := func(id int) []int {
+			calls++
+			if calls > 2 {
+				return []int{0, 1}
+			}
+			return []int{1}
+		}
+		if _, err := newRows(2, adjacency); err != ErrorInconsistentAdjacency {
+			t.Errorf("expected `%v` got `%v`", ErrorInconsistentAdjacency, err)
+		}
+	})
+}
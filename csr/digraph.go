@@ -0,0 +1,110 @@
+package csr
+
+import (
+	"github.com/danrl/golibby/graphalg"
+)
+
+// Digraph is an immutable directed graph in compressed sparse row format. It
+// stores outgoing and incoming edges, so that both directions are cheap to
+// iterate.
+type Digraph struct {
+	table
+	out rows
+	in  rows
+}
+
+// Digraph implements the read-only interface of the graph algorithms
+var _ graphalg.Digraph = (*Digraph)(nil)
+
+// NewDigraph creates a directed graph. Keys must be unique and in lexical
+// order, the ID of a node is its index in keys. Values are the values of the
+// nodes in the same order, or nil. The graph takes ownership of both slices.
+// The successors function is called twice for every node in ID order, first
+// to count and then to store the edges, and has to return the IDs of the nodes
+// the node has an edge pointing to both times.
+func NewDigraph(keys []string, values []interface{}, successors func(id int) []int) (*Digraph, error) {
+	t, err := newTable(keys, values)
+	if err != nil {
+		return nil, err
+	}
+	out, err := newRows(len(keys), successors)
+	if err != nil {
+		return nil, err
+	}
+	return &Digraph{table: t, out: out, in: out.transpose()}, nil
+}
+
+// SuccessorIDs returns the IDs of the nodes the node with the given ID has an
+// edge pointing to, in ascending order. The slice is shared with the graph and
+// must not be modified. It panics if the ID is out of range.
+func (g *Digraph) SuccessorIDs(id int) []int32 {
+	return g.out.row(id)
+}
+
+// PredecessorIDs returns the IDs of the nodes that have an edge pointing to the
+// node with the given ID, in ascending order. The slice is shared with the
+// graph and must not be modified. It panics if the ID is out of range.
+func (g *Digraph) PredecessorIDs(id int) []int32 {
+	return g.in.row(id)
+}
+
+// Neighbors returns the keys of nodes that the node has an edge pointing to in
+// lexical order
+func (g *Digraph) Neighbors(key string) ([]string, error) {
+	id, ok := g.ID(key)
+	if !ok {
+		return nil, ErrorNodeNotFound
+	}
+	return g.keysOf(g.out.row(id)), nil
+}
+
+// Predecessors returns the keys of nodes that have an edge pointing to the
+// node in lexical order
+func (g *Digraph) Predecessors(key string) ([]string, error) {
+	id, ok := g.ID(key)
+	if !ok {
+		return nil, ErrorNodeNotFound
+	}
+	return g.keysOf(g.in.row(id)), nil
+}
+
+// Edges returns the keys of nodes that the node has an edge pointing to
+func (g *Digraph) Edges(from string) ([]string, error) {
+	return g.Neighbors(from)
+}
+
+// InEdges returns the keys of nodes that have an edge pointing to the node
+func (g *Digraph) InEdges(to string) ([]string, error) {
+	return g.Predecessors(to)
+}
+
+// OutDegree returns the number of edges pointing away from the node
+func (g *Digraph) OutDegree(key string) (int, error) {
+	id, ok := g.ID(key)
+	if !ok {
+		return 0, ErrorNodeNotFound
+	}
+	return len(g.out.row(id)), nil
+}
+
+// InDegree returns the number of edges pointing to the node
+func (g *Digraph) InDegree(key string) (int, error) {
+	id, ok := g.ID(key)
+	if !ok {
+		return 0, ErrorNodeNotFound
+	}
+	return len(g.in.row(id)), nil
+}
+
+// HasEdge returns true if there is an edge pointing from one node to another
+func (g *Digraph) HasEdge(from, to string) bool {
+	a, ok := g.ID(from)
+	if !ok {
+		return false
+	}
+	b, ok := g.ID(to)
+	if !ok {
+		return false
+	}
+	return g.out.has(a, b)
+}
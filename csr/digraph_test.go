@@ -0,0 +1,83 @@
+package csr
+
+import (
+	"testing"
+)
+
+// chain returns a directed graph a -> b -> c -> a, a -> c and c -> d
+func chain() *Digraph {
+	g, _ := NewDigraph([]string{"a", "b", "c", "d"}, []interface{}{1, 2, 3, 4},
+		lists([]int{1, 2}, []int{2}, []int{0, 3}, nil))
+	return g
+}
+
+func TestNewDigraph(t *testing.T) {
+	if _, err := NewDigraph([]string{"a", "a"}, nil, lists(nil, nil)); err != ErrorInvalidKeys {
+		t.Errorf("expected `%v` got `%v`", ErrorInvalidKeys, err)
+	}
+	if _, err := NewDigraph([]string{"a"}, nil, lists([]int{-1})); err != ErrorInvalidID {
+		t.Errorf("expected `%v` got `%v`", ErrorInvalidID, err)
+	}
+}
+
+func TestDigraphNeighbors(t *testing.T) {
+	g := chain()
+	got, err := g.Neighbors("c")
+	if err != nil {
+		t.Errorf("expected `%v` got `%v`", nil, err)
+	}
+	if expected := []string{"a", "d"}; !equal(expected, got) {
+		t.Errorf("expected `%v` got `%v`", expected, got)
+	}
+	got, err = g.Predecessors("c")
+	if err != nil {
+		t.Errorf("expected `%v` got `%v`", nil, err)
+	}
+	if expected := []string{"a", "b"}; !equal(expected, got) {
+		t.Errorf("expected `%v` got `%v`", expected, got)
+	}
+	if got, _ := g.Edges("d"); len(got) != 0 {
+		t.Errorf("expected `%v` got `%v`", nil, got)
+	}
+	if got, _ := g.InEdges("a"); !equal([]string{"c"}, got) {
+		t.Errorf("expected `%v` got `%v`", []string{"c"}, got)
+	}
+	if !equalIDs([]int{1, 2}, g.SuccessorIDs(0)) {
+		t.Errorf("expected `%v` got `%v`", []int{1, 2}, g.SuccessorIDs(0))
+	}
+	if !equalIDs([]int{0, 1}, g.PredecessorIDs(2)) {
+		t.Errorf("expected `%v` got `%v`", []int{0, 1}, g.PredecessorIDs(2))
+	}
+	for _, fn := range []func(string) ([]string, error){g.Neighbors, g.Predecessors} {
+		if _, err := fn("x"); err != ErrorNodeNotFound {
+			t.Errorf("expected `%v` got `%v`", ErrorNodeNotFound, err)
+		}
+	}
+}
+
+func TestDigraphDegree(t *testing.T) {
+	g := chain()
+	if got, _ := g.OutDegree("a"); got != 2 {
+		t.Errorf("expected `%v` got `%v`", 2, got)
+	}
+	if got, _ := g.InDegree("a"); got != 1 {
+		t.Errorf("expected `%v` got `%v`", 1, got)
+	}
+	if _, err := g.OutDegree("x"); err != ErrorNodeNotFound {
+		t.Errorf("expected `%v` got `%v`", ErrorNodeNotFound, err)
+	}
+	if _, err := g.InDegree("x"); err != ErrorNodeNotFound {
+		t.Errorf("expected `%v` got `%v`", ErrorNodeNotFound, err)
+	}
+}
+
+func TestDigraphHasEdge(t *testing.T) {
+	g := chain()
+	if !g.HasEdge("c", "a") || g.HasEdge("a", "d") || g.HasEdge("d", "c") ||
+		g.HasEdge("x", "a") || g.HasEdge("a", "x") {
+		t.Errorf("unexpected edges")
+	}
+	if value, _ := g.Value("c"); value != 3 {
+		t.Errorf("expected `%v` got `%v`", 3, value)
+	}
+}
@@ -0,0 +1,77 @@
+package csr
+
+import (
+	"github.com/danrl/golibby/graphalg"
+)
+
+// Graph is an immutable undirected graph in compressed sparse row format
+type Graph struct {
+	table
+	adj rows
+}
+
+// Graph implements the read-only interface of the graph algorithms
+var _ graphalg.Graph = (*Graph)(nil)
+
+// NewGraph creates an undirected graph. Keys must be unique and in lexical
+// order, the ID of a node is its index in keys. Values are the values of the
+// nodes in the same order, or nil. The graph takes ownership of both slices.
+// The adjacency function is called twice for every node in ID order, first to
+// count and then to store the edges, and has to return the IDs of the
+// neighbors of the node both times. It must be symmetric, i.e. list every edge
+// at both of its nodes.
+func NewGraph(keys []string, values []interface{}, adjacency func(id int) []int) (*Graph, error) {
+	t, err := newTable(keys, values)
+	if err != nil {
+		return nil, err
+	}
+	adj, err := newRows(len(keys), adjacency)
+	if err != nil {
+		return nil, err
+	}
+	return &Graph{table: t, adj: adj}, nil
+}
+
+// NeighborIDs returns the IDs of the nodes directly connected to the node with
+// the given ID in ascending order. The slice is shared with the graph and must
+// not be modified. It panics if the ID is out of range.
+func (g *Graph) NeighborIDs(id int) []int32 {
+	return g.adj.row(id)
+}
+
+// Neighbors returns the keys of nodes that are directly connected to the node
+// in lexical order
+func (g *Graph) Neighbors(key string) ([]string, error) {
+	id, ok := g.ID(key)
+	if !ok {
+		return nil, ErrorNodeNotFound
+	}
+	return g.keysOf(g.adj.row(id)), nil
+}
+
+// Edges returns the keys of nodes that are directly connected to the node
+func (g *Graph) Edges(from string) ([]string, error) {
+	return g.Neighbors(from)
+}
+
+// Degree returns the number of nodes directly connected to the node
+func (g *Graph) Degree(key string) (int, error) {
+	id, ok := g.ID(key)
+	if !ok {
+		return 0, ErrorNodeNotFound
+	}
+	return len(g.adj.row(id)), nil
+}
+
+// HasEdge returns true if there is an edge between two nodes
+func (g *Graph) HasEdge(from, to string) bool {
+	a, ok := g.ID(from)
+	if !ok {
+		return false
+	}
+	b, ok := g.ID(to)
+	if !ok {
+		return false
+	}
+	return g.adj.has(a, b)
+}
@@ -0,0 +1,69 @@
+package csr
+
+import (
+	"testing"
+)
+
+// triangle returns a triangle a-b-c with a dangling node d attached to c and
+// an isolated node e
+func triangle() *Graph {
+	g, _ := NewGraph([]string{"a", "b", "c", "d", "e"}, nil,
+		lists([]int{1, 2}, []int{0, 2}, []int{0, 1, 3}, []int{2}, nil))
+	return g
+}
+
+func TestNewGraph(t *testing.T) {
+	if _, err := NewGraph([]string{"b", "a"}, nil, lists(nil, nil)); err != ErrorInvalidKeys {
+		t.Errorf("expected `%v` got `%v`", ErrorInvalidKeys, err)
+	}
+	if _, err := NewGraph([]string{"a"}, nil, lists([]int{1})); err != ErrorInvalidID {
+		t.Errorf("expected `%v` got `%v`", ErrorInvalidID, err)
+	}
+}
+
+func TestGraphNeighbors(t *testing.T) {
+	g := triangle()
+	got, err := g.Neighbors("c")
+	if err != nil {
+		t.Errorf("expected `%v` got `%v`", nil, err)
+	}
+	expected := []string{"a", "b", "d"}
+	if !equal(expected, got) {
+		t.Errorf("expected `%v` got `%v`", expected, got)
+	}
+	if got, _ := g.Edges("e"); len(got) != 0 {
+		t.Errorf("expected `%v` got `%v`", nil, got)
+	}
+	if !equalIDs([]int{0, 1, 3}, g.NeighborIDs(2)) {
+		t.Errorf("expected `%v` got `%v`", []int{0, 1, 3}, g.NeighborIDs(2))
+	}
+	if degree, _ := g.Degree("a"); degree != 2 {
+		t.Errorf("expected `%v` got `%v`", 2, degree)
+	}
+	if _, err := g.Neighbors("x"); err != ErrorNodeNotFound {
+		t.Errorf("expected `%v` got `%v`", ErrorNodeNotFound, err)
+	}
+	if _, err := g.Degree("x"); err != ErrorNodeNotFound {
+		t.Errorf("expected `%v` got `%v`", ErrorNodeNotFound, err)
+	}
+}
+
+func TestGraphHasEdge(t *testing.T) {
+	g := triangle()
+	tt := []struct {
+		from, to string
+		expected bool
+	}{
+		{from: "a", to: "b", expected: true},
+		{from: "d", to: "c", expected: true},
+		{from: "a", to: "d", expected: false},
+		{from: "a", to: "x", expected: false},
+		{from: "x", to: "a", expected: false},
+	}
+	for _, tc := range tt {
+		if got := g.HasEdge(tc.from, tc.to); got != tc.expected {
+			t.Errorf("`%v`-`%v`: expected `%v` got `%v`", tc.from, tc.to,
+				tc.expected, got)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package directedgraph
+
+import "math"
+
+// DefaultCapacity is the capacity of edges that have not been assigned a
+// capacity explicitly
+const DefaultCapacity = 1.0
+
+// setCapacity stores the capacity of an edge without acquiring the lock
+func (g *DirectedGraph) setCapacity(from, to string, capacity float64) {
+	if g.capacities[from] == nil {
+		g.capacities[from] = make(map[string]float64)
+	}
+	g.capacities[from][to] = capacity
+}
+
+// validCapacity reports whether a capacity is a finite, non-negative number
+func validCapacity(capacity float64) bool {
+	return capacity >= 0 && !math.IsInf(capacity, 1)
+}
+
+// capacity returns the capacity of an existing edge without acquiring the lock
+func (g *DirectedGraph) capacity(from, to string) float64 {
+	if capacity, ok := g.capacities[from][to]; ok {
+		return capacity
+	}
+	return DefaultCapacity
+}
+
+// NewEdgeWithCapacity adds an edge between to nodes in the graph and assigns
+// a capacity to it. If the edge exists already, only its capacity is updated.
+// ErrorInvalidCapacity is returned for negative, infinite and NaN capacities.
+func (g *DirectedGraph) NewEdgeWithCapacity(from, to string, capacity float64) error {
+	if !validCapacity(capacity) {
+		return ErrorInvalidCapacity
+	}
+
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	if err := g.newEdge(from, to); err != nil {
+		return err
+	}
+	g.setCapacity(from, to, capacity)
+	return nil
+}
+
+// SetCapacity assigns a capacity to an existing edge. ErrorInvalidCapacity is
+// returned for negative, infinite and NaN capacities.
+func (g *DirectedGraph) SetCapacity(from, to string, capacity float64) error {
+	if !validCapacity(capacity) {
+		return ErrorInvalidCapacity
+	}
+
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	if _, ok := g.nodes[from]; !ok {
+		return ErrorNodeNotFound
+	}
+	if _, ok := g.nodes[to]; !ok {
+		return ErrorNodeNotFound
+	}
+	if !g.edges[from][to] {
+		return ErrorEdgeNotFound
+	}
+	g.setCapacity(from, to, capacity)
+	return nil
+}
+
+// Capacity returns the capacity of an edge. Edges that have not been assigned
+// a capacity have DefaultCapacity.
+func (g *DirectedGraph) Capacity(from, to string) (float64, error) {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+
+	if _, ok := g.nodes[from]; !ok {
+		return 0, ErrorNodeNotFound
+	}
+	if _, ok := g.nodes[to]; !ok {
+		return 0, ErrorNodeNotFound
+	}
+	if !g.edges[from][to] {
+		return 0, ErrorEdgeNotFound
+	}
+	return g.capacity(from, to), nil
+}
+
+// capacitySnapshot returns a copy of the capacities of all edges without
+// acquiring the lock
+func (g *DirectedGraph) capacitySnapshot() map[string]map[string]float64 {
+	capacities := make(map[string]map[string]float64, len(g.nodes))
+	for from := range g.nodes {
+		capacities[from] = make(map[string]float64, len(g.edges[from]))
+		for to := range g.edges[from] {
+			capacities[from][to] = g.capacity(from, to)
+		}
+	}
+	return capacities
+}
+
+// Capacities returns the capacities of all edges, keyed by the node the edge
+// starts at and the node it points to. Every node has an entry, which is empty
+// if the node has no outgoing edges. The result is a snapshot of the graph
+// taken under a single lock.
+func (g *DirectedGraph) Capacities() map[string]map[string]float64 {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+
+	return g.capacitySnapshot()
+}
@@ -0,0 +1,160 @@
+package directedgraph
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func TestGraphCapacity(t *testing.T) {
+	t.Run("default capacity", func(t *testing.T) {
+		g := New()
+		g.NewNode("a", nil)
+		g.NewNode("b", nil)
+		g.NewEdge("a", "b")
+		got, err := g.Capacity("a", "b")
+		if err != nil {
+			t.Errorf("expected `%v` got `%v`", nil, err)
+		}
+		if got != DefaultCapacity {
+			t.Errorf("expected `%v` got `%v`", DefaultCapacity, got)
+		}
+	})
+	t.Run("assigned capacity", func(t *testing.T) {
+		g := New()
+		g.NewNode("a", nil)
+		g.NewNode("b", nil)
+		if err := g.NewEdgeWithCapacity("a", "b", 5); err != nil {
+			t.Errorf("expected `%v` got `%v`", nil, err)
+		}
+		if got, _ := g.Capacity("a", "b"); got != 5 {
+			t.Errorf("expected `%v` got `%v`", 5, got)
+		}
+		if err := g.SetCapacity("a", "b", 0); err != nil {
+			t.Errorf("expected `%v` got `%v`", nil, err)
+		}
+		if got, _ := g.Capacity("a", "b"); got != 0 {
+			t.Errorf("expected `%v` got `%v`", 0, got)
+		}
+		if got, _ := g.Transpose().Capacity("b", "a"); got != 0 {
+			t.Errorf("expected `%v` got `%v`", 0, got)
+		}
+	})
+	t.Run("removed edge", func(t *testing.T) {
+		g := New()
+		g.NewNode("a", nil)
+		g.NewNode("b", nil)
+		g.NewNode("c", nil)
+		g.NewEdgeWithCapacity("a", "b", 5)
+		g.NewEdgeWithCapacity("c", "a", 5)
+		g.DeleteEdge("a", "b")
+		g.NewEdge("a", "b")
+		if got, _ := g.Capacity("a", "b"); got != DefaultCapacity {
+			t.Errorf("expected `%v` got `%v`", DefaultCapacity, got)
+		}
+		g.DeleteNode("a")
+		if len(g.capacities["c"]) != 0 {
+			t.Errorf("dangling capacity `%v`->`%v`", "c", "a")
+		}
+	})
+	t.Run("invalid capacity", func(t *testing.T) {
+		g := New()
+		g.NewNode("a", nil)
+		g.NewNode("b", nil)
+		if err := g.NewEdgeWithCapacity("a", "b", -1); err != ErrorInvalidCapacity {
+			t.Errorf("expected `%v` got `%v`", ErrorInvalidCapacity, err)
+		}
+		if g.edges["a"]["b"] {
+			t.Errorf("unexpected edge `%v`->`%v` found.", "a", "b")
+		}
+		g.NewEdge("a", "b")
+		if err := g.SetCapacity("a", "b", -1); err != ErrorInvalidCapacity {
+			t.Errorf("expected `%v` got `%v`", ErrorInvalidCapacity, err)
+		}
+	})
+	t.Run("non-finite capacity", func(t *testing.T) {
+		for _, capacity := range []float64{math.NaN(), math.Inf(1)} {
+			g := New()
+			g.NewNode("a", nil)
+			g.NewNode("b", nil)
+			if err := g.NewEdgeWithCapacity("a", "b", capacity); err != ErrorInvalidCapacity {
+				t.Errorf("expected `%v` got `%v`", ErrorInvalidCapacity, err)
+			}
+			if g.edges["a"]["b"] {
+				t.Errorf("unexpected edge `%v`->`%v` found.", "a", "b")
+			}
+			g.NewEdge("a", "b")
+			if err := g.SetCapacity("a", "b", capacity); err != ErrorInvalidCapacity {
+				t.Errorf("expected `%v` got `%v`", ErrorInvalidCapacity, err)
+			}
+			if got, _ := g.Capacity("a", "b"); got != DefaultCapacity {
+				t.Errorf("expected `%v` got `%v`", DefaultCapacity, got)
+			}
+		}
+	})
+	t.Run("unknown edges", func(t *testing.T) {
+		g := New()
+		g.NewNode("a", nil)
+		g.NewNode("b", nil)
+		if _, err := g.Capacity("a", "b"); err != ErrorEdgeNotFound {
+			t.Errorf("expected `%v` got `%v`", ErrorEdgeNotFound, err)
+		}
+		if _, err := g.Capacity("a", "c"); err != ErrorNodeNotFound {
+			t.Errorf("expected `%v` got `%v`", ErrorNodeNotFound, err)
+		}
+		if err := g.SetCapacity("a", "b", 1); err != ErrorEdgeNotFound {
+			t.Errorf("expected `%v` got `%v`", ErrorEdgeNotFound, err)
+		}
+		if err := g.SetCapacity("c", "b", 1); err != ErrorNodeNotFound {
+			t.Errorf("expected `%v` got `%v`", ErrorNodeNotFound, err)
+		}
+		if err := g.NewEdgeWithCapacity("a", "c", 1); err != ErrorNodeNotFound {
+			t.Errorf("expected `%v` got `%v`", ErrorNodeNotFound, err)
+		}
+	})
+	t.Run("json round trip", func(t *testing.T) {
+		g := New()
+		g.NewNode("a", nil)
+		g.NewNode("b", nil)
+		g.NewNode("c", nil)
+		g.NewEdgeWithCapacity("a", "b", 2.5)
+		g.NewEdge("b", "c")
+		data, _ := json.Marshal(g)
+		expected := `{"directed":true,"nodes":[{"id":"a"},{"id":"b"},{"id":"c"}],` +
+			`"links":[{"source":"a","target":"b","capacity":2.5},` +
+			`{"source":"b","target":"c"}]}`
+		if string(data) != expected {
+			t.Errorf("expected `%s` got `%s`", expected, data)
+		}
+		r := New()
+		if err := json.Unmarshal(data, r); err != nil {
+			t.Fatalf("expected `%v` got `%v`", nil, err)
+		}
+		if got, _ := r.Capacity("a", "b"); got != 2.5 {
+			t.Errorf("expected `%v` got `%v`", 2.5, got)
+		}
+		if _, ok := r.capacities["b"]["c"]; ok {
+			t.Errorf("unexpected explicit capacity for `%v`->`%v`", "b", "c")
+		}
+	})
+}
+
+func TestGraphCapacities(t *testing.T) {
+	g := New()
+	g.NewNode("a", nil)
+	g.NewNode("b", nil)
+	g.NewNode("c", nil)
+	g.NewEdgeWithCapacity("a", "b", 2.5)
+	g.NewEdge("b", "c")
+	got := g.Capacities()
+	if len(got) != 3 || len(got["c"]) != 0 {
+		t.Errorf("unexpected capacities `%v`", got)
+	}
+	if got["a"]["b"] != 2.5 || got["b"]["c"] != DefaultCapacity {
+		t.Errorf("unexpected capacities `%v`", got)
+	}
+	got["a"]["b"] = 7
+	if capacity, _ := g.Capacity("a", "b"); capacity != 2.5 {
+		t.Errorf("expected `%v` got `%v`", 2.5, capacity)
+	}
+}
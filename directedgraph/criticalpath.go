@@ -0,0 +1,123 @@
+package directedgraph
+
+// Schedule holds the result of a critical path analysis
+type Schedule struct {
+	// Path holds the keys of the nodes along a longest (critical) path
+	Path []string
+	// Length is the total duration of the critical path
+	Length float64
+	// EarliestStart holds the earliest start time of every node
+	EarliestStart map[string]float64
+	// LatestStart holds the latest start time of every node that does not
+	// delay the completion of all nodes
+	LatestStart map[string]float64
+	// Slack holds the difference between latest and earliest start time of
+	// every node. Nodes along the critical path have zero slack.
+	Slack map[string]float64
+}
+
+// tasks is a copy of an acyclic graph in topological order, taken so that
+// the duration function can be called without holding the lock
+type tasks struct {
+	order        []string
+	values       map[string]interface{}
+	predecessors map[string][]string
+	successors   map[string][]string
+}
+
+// tasks copies the graph in topological order. ErrorGraphIsCyclic is returned
+// if the graph is not acyclic.
+func (g *DirectedGraph) tasks() (*tasks, error) {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+
+	t := &tasks{}
+	if g.order != nil {
+		t.order = g.maintainedOrder()
+	} else {
+		if g.isCyclic() {
+			return nil, ErrorGraphIsCyclic
+		}
+		t.order = g.topOrder()
+	}
+	t.values = make(map[string]interface{}, len(t.order))
+	t.predecessors = make(map[string][]string, len(t.order))
+	t.successors = make(map[string][]string, len(t.order))
+	for _, key := range t.order {
+		t.values[key] = g.nodes[key]
+		t.predecessors[key] = sortedKeys(g.reverse[key])
+		t.successors[key] = sortedKeys(g.edges[key])
+	}
+	return t, nil
+}
+
+// CriticalPath interprets the graph as a network of tasks where an edge from
+// one node to another means that the first task has to finish before the
+// second can start. The duration function returns the duration of every task
+// and is called exactly once per node, on a copy of the graph taken under the
+// lock. The returned schedule contains a longest path through the graph along
+// with earliest and latest start times and slack of every node.
+// ErrorGraphIsCyclic is returned if the graph is not acyclic.
+func (g *DirectedGraph) CriticalPath(duration func(key string, value interface{}) float64) (*Schedule, error) {
+	t, err := g.tasks()
+	if err != nil {
+		return nil, err
+	}
+	order := t.order
+
+	s := &Schedule{
+		EarliestStart: make(map[string]float64, len(order)),
+		LatestStart:   make(map[string]float64, len(order)),
+		Slack:         make(map[string]float64, len(order)),
+	}
+	d := make(map[string]float64, len(order))
+	for _, key := range order {
+		d[key] = duration(key, t.values[key])
+	}
+
+	// forward pass: a node starts as soon as all its predecessors finished.
+	// the predecessor finishing last is remembered to reconstruct the path.
+	critical := make(map[string]string)
+	var end string
+	for i, key := range order {
+		start := 0.0
+		for _, from := range t.predecessors[key] {
+			_, ok := critical[key]
+			if finish := s.EarliestStart[from] + d[from]; !ok || finish > start {
+				start = finish
+				critical[key] = from
+			}
+		}
+		s.EarliestStart[key] = start
+		if finish := start + d[key]; i == 0 || finish > s.Length ||
+			finish == s.Length && key < end {
+			s.Length = finish
+			end = key
+		}
+	}
+
+	// backward pass: a node has to start early enough for all its successors
+	// to start in time
+	for i := len(order) - 1; i >= 0; i-- {
+		key := order[i]
+		finish := s.Length
+		for _, to := range t.successors[key] {
+			if s.LatestStart[to] < finish {
+				finish = s.LatestStart[to]
+			}
+		}
+		s.LatestStart[key] = finish - d[key]
+		s.Slack[key] = s.LatestStart[key] - s.EarliestStart[key]
+	}
+
+	if len(order) > 0 {
+		for key, ok := end, true; ok; key, ok = critical[key] {
+			s.Path = append(s.Path, key)
+		}
+		// path was collected from its end
+		for l, r := 0, len(s.Path)-1; l < r; l, r = l+1, r-1 {
+			s.Path[l], s.Path[r] = s.Path[r], s.Path[l]
+		}
+	}
+	return s, nil
+}
@@ -0,0 +1,145 @@
+package directedgraph
+
+import (
+	"testing"
+)
+
+// duration interprets node values as durations
+func duration(key string, value interface{}) float64 {
+	return value.(float64)
+}
+
+func TestGraphCriticalPath(t *testing.T) {
+	t.Run("empty graph", func(t *testing.T) {
+		s, err := New().CriticalPath(duration)
+		if err != nil {
+			t.Fatalf("expected `%v` got `%v`", nil, err)
+		}
+		if len(s.Path) != 0 || s.Length != 0 {
+			t.Errorf("expected empty schedule, got `%v` `%v`", s.Path, s.Length)
+		}
+	})
+	t.Run("project network", func(t *testing.T) {
+		for _, g := range []*DirectedGraph{New(), NewDAG()} {
+			g.NewNode("a", 3.0)
+			g.NewNode("b", 2.0)
+			g.NewNode("c", 4.0)
+			g.NewNode("d", 2.0)
+			g.NewNode("e", 1.0)
+			g.NewNode("f", 1.0)
+			g.NewEdge("a", "b")
+			g.NewEdge("a", "c")
+			g.NewEdge("a", "f")
+			g.NewEdge("b", "d")
+			g.NewEdge("c", "d")
+			g.NewEdge("d", "e")
+			s, err := g.CriticalPath(duration)
+			if err != nil {
+				t.Fatalf("expected `%v` got `%v`", nil, err)
+			}
+			expected := []string{"a", "c", "d", "e"}
+			if !equal(expected, s.Path) {
+				t.Errorf("expected path `%v` got `%v`", expected, s.Path)
+			}
+			if s.Length != 10 {
+				t.Errorf("expected length `%v` got `%v`", 10, s.Length)
+			}
+			tt := []struct {
+				key              string
+				earliest, latest float64
+				slack            float64
+			}{
+				{key: "a", earliest: 0, latest: 0, slack: 0},
+				{key: "b", earliest: 3, latest: 5, slack: 2},
+				{key: "c", earliest: 3, latest: 3, slack: 0},
+				{key: "d", earliest: 7, latest: 7, slack: 0},
+				{key: "e", earliest: 9, latest: 9, slack: 0},
+				{key: "f", earliest: 3, latest: 9, slack: 6},
+			}
+			for _, tc := range tt {
+				if got := s.EarliestStart[tc.key]; got != tc.earliest {
+					t.Errorf("node `%v`: expected earliest start `%v` got `%v`",
+						tc.key, tc.earliest, got)
+				}
+				if got := s.LatestStart[tc.key]; got != tc.latest {
+					t.Errorf("node `%v`: expected latest start `%v` got `%v`",
+						tc.key, tc.latest, got)
+				}
+				if got := s.Slack[tc.key]; got != tc.slack {
+					t.Errorf("node `%v`: expected slack `%v` got `%v`",
+						tc.key, tc.slack, got)
+				}
+			}
+		}
+	})
+	t.Run("independent nodes", func(t *testing.T) {
+		g := New()
+		g.NewNode("a", 1.0)
+		g.NewNode("b", 2.0)
+		g.NewNode("c", 2.0)
+		s, err := g.CriticalPath(duration)
+		if err != nil {
+			t.Fatalf("expected `%v` got `%v`", nil, err)
+		}
+		expected := []string{"b"}
+		if !equal(expected, s.Path) {
+			t.Errorf("expected path `%v` got `%v`", expected, s.Path)
+		}
+		if s.Slack["a"] != 1 {
+			t.Errorf("expected slack `%v` got `%v`", 1, s.Slack["a"])
+		}
+	})
+	t.Run("empty key", func(t *testing.T) {
+		g := New()
+		g.NewNode("", 5.0)
+		g.NewNode("a", 1.0)
+		g.NewNode("b", 2.0)
+		g.NewEdge("", "a")
+		g.NewEdge("b", "a")
+		s, err := g.CriticalPath(duration)
+		if err != nil {
+			t.Fatalf("expected `%v` got `%v`", nil, err)
+		}
+		if expected := []string{"", "a"}; !equal(expected, s.Path) {
+			t.Errorf("expected `%v` got `%v`", expected, s.Path)
+		}
+		if s.Length != 6 {
+			t.Errorf("expected `%v` got `%v`", 6, s.Length)
+		}
+	})
+	t.Run("callback using the graph", func(t *testing.T) {
+		g := New()
+		g.NewNode("a", 1.0)
+		g.NewNode("b", 2.0)
+		g.NewEdge("a", "b")
+		s, err := g.CriticalPath(func(key string, _ interface{}) float64 {
+			// a writer waiting for the lock must not block readers called
+			// from within the callback
+			done := make(chan struct{})
+			go func() {
+				g.UpdateValue(key, 3.0)
+				close(done)
+			}()
+			<-done
+			value, _ := g.Value(key)
+			return value.(float64)
+		})
+		if err != nil {
+			t.Fatalf("expected `%v` got `%v`", nil, err)
+		}
+		if s.Length != 6 {
+			t.Errorf("expected `%v` got `%v`", 6, s.Length)
+		}
+	})
+	t.Run("cyclic graph", func(t *testing.T) {
+		g := New()
+		g.NewNode("a", 1.0)
+		g.NewNode("b", 1.0)
+		g.NewEdge("a", "b")
+		g.NewEdge("b", "a")
+		_, err := g.CriticalPath(duration)
+		if err != ErrorGraphIsCyclic {
+			t.Errorf("expected `%v` got `%v`", ErrorGraphIsCyclic, err)
+		}
+	})
+}
@@ -0,0 +1,133 @@
+package directedgraph
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// CycleError is returned when adding an edge to a graph created by NewDAG
+// would create a cycle. It wraps ErrorGraphIsCyclic, so that
+// errors.Is(err, ErrorGraphIsCyclic) holds.
+type CycleError struct {
+	// Cycle holds the keys of the nodes along the cycle that the rejected
+	// edge would have created, starting with the edge's source node and
+	// ending with the same node again.
+	Cycle []string
+}
+
+func (e *CycleError) Error() string {
+	return fmt.Sprintf("%v: %v", ErrorGraphIsCyclic, strings.Join(e.Cycle, " -> "))
+}
+
+// Unwrap returns ErrorGraphIsCyclic
+func (e *CycleError) Unwrap() error {
+	return ErrorGraphIsCyclic
+}
+
+// NewDAG initializes a new graph that is guaranteed to be acyclic at all
+// times. NewEdge refuses to add edges that would create a cycle. A topological
+// order of all nodes is maintained incrementally using the algorithm by Pearce
+// and Kelly, so that adding an edge only visits the nodes whose position in
+// the order is affected by the new edge.
+func NewDAG() *DirectedGraph {
+	g := New()
+	g.order = make(map[string]int)
+	return g
+}
+
+// reorder updates the topological order of the graph prior to adding an edge
+// from one node to another. It returns a *CycleError if the edge would create
+// a cycle, in which case the order is left untouched.
+func (g *DirectedGraph) reorder(from, to string) error {
+	if from == to {
+		return &CycleError{Cycle: []string{from, to}}
+	}
+	lower, upper := g.order[to], g.order[from]
+	if lower > upper {
+		// edge does not violate the current order
+		return nil
+	}
+
+	// discover nodes reachable from `to` that are ordered before `from`. if
+	// `from` is among them, the new edge would close a cycle.
+	forward := []string{to}
+	parent := map[string]string{to: to}
+	for i := 0; i < len(forward); i++ {
+		for next := range g.edges[forward[i]] {
+			if _, seen := parent[next]; seen {
+				continue
+			}
+			if next == from {
+				return &CycleError{Cycle: cyclePath(parent, from, to, forward[i])}
+			}
+			if g.order[next] < upper {
+				parent[next] = forward[i]
+				forward = append(forward, next)
+			}
+		}
+	}
+
+	// discover nodes that reach `from` and are ordered after `to`
+	backward := []string{from}
+	seen := map[string]bool{from: true}
+	for i := 0; i < len(backward); i++ {
+		for prev := range g.reverse[backward[i]] {
+			if !seen[prev] && g.order[prev] > lower {
+				seen[prev] = true
+				backward = append(backward, prev)
+			}
+		}
+	}
+
+	// reassign the positions occupied by both sets, moving the backward set
+	// in front of the forward set while keeping the relative order within
+	// each set
+	byOrder := func(keys []string) {
+		sort.Slice(keys, func(i, j int) bool {
+			return g.order[keys[i]] < g.order[keys[j]]
+		})
+	}
+	byOrder(forward)
+	byOrder(backward)
+	affected := append(backward, forward...)
+	positions := make([]int, len(affected))
+	for i, key := range affected {
+		positions[i] = g.order[key]
+	}
+	sort.Ints(positions)
+	for i, key := range affected {
+		g.order[key] = positions[i]
+	}
+	return nil
+}
+
+// cyclePath follows the parent pointers from last back to the first node
+// and returns the cycle closed by an edge from start to first
+func cyclePath(parent map[string]string, start, first, last string) []string {
+	var path []string
+	for key := last; ; key = parent[key] {
+		path = append(path, key)
+		if key == first {
+			break
+		}
+	}
+	cycle := []string{start}
+	for i := len(path) - 1; i >= 0; i-- {
+		cycle = append(cycle, path[i])
+	}
+	return append(cycle, start)
+}
+
+// maintainedOrder returns all node keys sorted by their maintained
+// topological order
+func (g *DirectedGraph) maintainedOrder() []string {
+	keys := make([]string, 0, len(g.order))
+	for key := range g.order {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return g.order[keys[i]] < g.order[keys[j]]
+	})
+	return keys
+}
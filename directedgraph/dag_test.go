@@ -0,0 +1,172 @@
+package directedgraph
+
+import (
+	"errors"
+	"math/rand"
+	"strconv"
+	"testing"
+)
+
+// validOrder tests if order is a topological order of all nodes of the graph
+func validOrder(g *DirectedGraph, order []string) bool {
+	if len(order) != len(g.nodes) {
+		return false
+	}
+	position := make(map[string]int)
+	for i, key := range order {
+		position[key] = i
+	}
+	for from := range g.edges {
+		for to := range g.edges[from] {
+			if position[from] >= position[to] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func TestNewDAG(t *testing.T) {
+	g := NewDAG()
+	if len(g.nodes) != 0 || len(g.order) != 0 {
+		t.Errorf("initial node list not empty")
+	}
+	g.NewNode("a", nil)
+	g.NewNode("b", nil)
+	if g.order["a"] >= g.order["b"] {
+		t.Errorf("expected nodes to be ordered by creation")
+	}
+	g.DeleteNode("a")
+	if _, ok := g.order["a"]; ok {
+		t.Errorf("deleted node still present in order")
+	}
+}
+
+func TestDAGNewEdge(t *testing.T) {
+	t.Run("edges in creation order", func(t *testing.T) {
+		g := NewDAG()
+		for _, key := range []string{"a", "b", "c"} {
+			g.NewNode(key, nil)
+		}
+		for _, e := range [][2]string{{"a", "b"}, {"b", "c"}, {"a", "c"}} {
+			if err := g.NewEdge(e[0], e[1]); err != nil {
+				t.Errorf("edge from `%v` to `%v`: %v", e[0], e[1], err)
+			}
+		}
+		expected := []string{"a", "b", "c"}
+		if got := g.TopSort(); !equal(expected, got) {
+			t.Errorf("expected `%v` got `%v`", expected, got)
+		}
+	})
+	t.Run("edges against creation order", func(t *testing.T) {
+		g := NewDAG()
+		for _, key := range []string{"a", "b", "c", "d", "e"} {
+			g.NewNode(key, nil)
+		}
+		for _, e := range [][2]string{{"d", "c"}, {"c", "b"}, {"e", "d"},
+			{"b", "a"}, {"e", "a"}} {
+			if err := g.NewEdge(e[0], e[1]); err != nil {
+				t.Errorf("edge from `%v` to `%v`: %v", e[0], e[1], err)
+			}
+		}
+		expected := []string{"e", "d", "c", "b", "a"}
+		if got := g.TopSort(); !equal(expected, got) {
+			t.Errorf("expected `%v` got `%v`", expected, got)
+		}
+	})
+	t.Run("cycle", func(t *testing.T) {
+		g := NewDAG()
+		for _, key := range []string{"a", "b", "c", "d"} {
+			g.NewNode(key, nil)
+		}
+		g.NewEdge("a", "b")
+		g.NewEdge("b", "c")
+		g.NewEdge("c", "d")
+		err := g.NewEdge("d", "b")
+		if !errors.Is(err, ErrorGraphIsCyclic) {
+			t.Fatalf("expected `%v` got `%v`", ErrorGraphIsCyclic, err)
+		}
+		var cerr *CycleError
+		if !errors.As(err, &cerr) {
+			t.Fatalf("expected *CycleError got `%T`", err)
+		}
+		expected := []string{"d", "b", "c", "d"}
+		if !equal(expected, cerr.Cycle) {
+			t.Errorf("expected `%v` got `%v`", expected, cerr.Cycle)
+		}
+		if g.edges["d"]["b"] || g.reverse["b"]["d"] {
+			t.Errorf("unexpected edge `%v`->`%v` found.", "d", "b")
+		}
+		if !validOrder(g, g.TopSort()) {
+			t.Errorf("invalid topological order `%v`", g.TopSort())
+		}
+	})
+	t.Run("self-referencing node", func(t *testing.T) {
+		g := NewDAG()
+		g.NewNode("", nil)
+		err := g.NewEdge("", "")
+		var cerr *CycleError
+		if !errors.As(err, &cerr) {
+			t.Fatalf("expected *CycleError got `%T`", err)
+		}
+		expected := []string{"", ""}
+		if !equal(expected, cerr.Cycle) {
+			t.Errorf("expected `%v` got `%v`", expected, cerr.Cycle)
+		}
+	})
+	t.Run("random edges", func(t *testing.T) {
+		rng := rand.New(rand.NewSource(42))
+		g := NewDAG()
+		for i := 0; i < 50; i++ {
+			g.NewNode(strconv.Itoa(i), nil)
+		}
+		for i := 0; i < 500; i++ {
+			from := strconv.Itoa(rng.Intn(50))
+			to := strconv.Itoa(rng.Intn(50))
+			err := g.NewEdge(from, to)
+			if err == nil {
+				continue
+			}
+			var cerr *CycleError
+			if !errors.As(err, &cerr) {
+				t.Fatalf("expected *CycleError got `%v`", err)
+			}
+			// the reported cycle must consist of the rejected edge and
+			// existing edges
+			c := cerr.Cycle
+			if c[0] != from || c[1] != to || c[len(c)-1] != from {
+				t.Fatalf("cycle `%v` does not contain edge `%v`->`%v`", c, from, to)
+			}
+			for j := 1; j+1 < len(c); j++ {
+				if !g.edges[c[j]][c[j+1]] {
+					t.Fatalf("cycle `%v` contains unknown edge `%v`->`%v`", c,
+						c[j], c[j+1])
+				}
+			}
+		}
+		if g.IsCyclic() {
+			t.Errorf("expected `false` got `true`")
+		}
+		if !validOrder(g, g.TopSort()) {
+			t.Errorf("invalid topological order `%v`", g.TopSort())
+		}
+	})
+}
+
+func TestDAGUnmarshalJSON(t *testing.T) {
+	g := NewDAG()
+	err := g.UnmarshalJSON([]byte(`{"directed":true,"nodes":[{"id":"a"},` +
+		`{"id":"b"}],"links":[{"source":"b","target":"a"}]}`))
+	if err != nil {
+		t.Fatalf("expected `%v` got `%v`", nil, err)
+	}
+	expected := []string{"b", "a"}
+	if got := g.TopSort(); !equal(expected, got) {
+		t.Errorf("expected `%v` got `%v`", expected, got)
+	}
+	err = g.UnmarshalJSON([]byte(`{"directed":true,"nodes":[{"id":"a"}],` +
+		`"links":[{"source":"a","target":"a"}]}`))
+	if !errors.Is(err, ErrorGraphIsCyclic) {
+		t.Errorf("expected `%v` got `%v`", ErrorGraphIsCyclic, err)
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"bytes"
 	"fmt"
 	"sync"
+
+	"github.com/danrl/golibby/graphalg"
 )
 
 var (
@@ -13,23 +15,45 @@ var (
 	ErrorNodeNotFound = fmt.Errorf("node not found")
 	// ErrorNodeAlreadyExists is returned when trying to create duplicate nodes
 	ErrorNodeAlreadyExists = fmt.Errorf("node already exists")
+	// ErrorEdgeNotFound is returned when trying to access a non-existent edge
+	ErrorEdgeNotFound = fmt.Errorf("edge not found")
+	// ErrorInvalidCapacity is returned when trying to assign a negative,
+	// infinite or NaN capacity to an edge
+	ErrorInvalidCapacity = fmt.Errorf("invalid capacity")
+	// ErrorInvalidFormat is returned when decoding a graph from malformed input
+	ErrorInvalidFormat = fmt.Errorf("invalid format")
 	// ErrorGraphIsCyclic is returned when trying to perform an operation on a
 	// cyclic graph that requires the graph to be acyclic
 	ErrorGraphIsCyclic = fmt.Errorf("graph is cyclic")
 )
 
-// DirectedGraph holds a directed graph data structure
+// DirectedGraph holds a directed graph data structure. Besides the adjacency
+// map `edges` it maintains the reverse adjacency map `reverse`, so that
+// predecessors of a node can be found without scanning the whole graph.
+// Capacities are only stored for edges with an explicitly assigned capacity.
+// Graphs created by NewDAG also maintain a topological `order` of all nodes.
 type DirectedGraph struct {
-	lock  sync.RWMutex
-	nodes map[string]interface{}
-	edges map[string]map[string]bool
+	lock        sync.RWMutex
+	nodes       map[string]interface{}
+	edges       map[string]map[string]bool
+	reverse     map[string]map[string]bool
+	capacities  map[string]map[string]float64
+	order       map[string]int
+	nextOrder   int
+	subscribers []*Subscription
+	sequence    uint64
 }
 
+// DirectedGraph implements the read-only interface of the graph algorithms
+var _ graphalg.Digraph = (*DirectedGraph)(nil)
+
 // New initializes a new graph
 func New() *DirectedGraph {
 	return &DirectedGraph{
-		nodes: make(map[string]interface{}),
-		edges: make(map[string]map[string]bool),
+		nodes:      make(map[string]interface{}),
+		edges:      make(map[string]map[string]bool),
+		reverse:    make(map[string]map[string]bool),
+		capacities: make(map[string]map[string]float64),
 	}
 }
 
@@ -43,6 +67,48 @@ func (g *DirectedGraph) NewNode(key string, value interface{}) error {
 	}
 	g.nodes[key] = value
 	g.edges[key] = make(map[string]bool)
+	g.reverse[key] = make(map[string]bool)
+	if g.order != nil {
+		g.order[key] = g.nextOrder
+		g.nextOrder++
+	}
+	g.emit(Event{Type: NodeAdded, Key: key, Value: value})
+
+	return nil
+}
+
+// DeleteNode removes the node identified by key from the graph together with
+// all edges leading to or coming from it
+func (g *DirectedGraph) DeleteNode(key string) error {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	if _, ok := g.nodes[key]; !ok {
+		return ErrorNodeNotFound
+	}
+	if len(g.subscribers) > 0 {
+		for _, to := range sortedKeys(g.edges[key]) {
+			g.emit(Event{Type: EdgeRemoved, From: key, To: to})
+		}
+		for _, from := range sortedKeys(g.reverse[key], key) {
+			g.emit(Event{Type: EdgeRemoved, From: from, To: key})
+		}
+	}
+	for to := range g.edges[key] {
+		delete(g.reverse[to], key)
+	}
+	for from := range g.reverse[key] {
+		delete(g.edges[from], key)
+		delete(g.capacities[from], key)
+	}
+	delete(g.edges, key)
+	delete(g.reverse, key)
+	delete(g.capacities, key)
+	delete(g.nodes, key)
+	if g.order != nil {
+		delete(g.order, key)
+	}
+	g.emit(Event{Type: NodeRemoved, Key: key})
 
 	return nil
 }
@@ -68,22 +134,61 @@ func (g *DirectedGraph) UpdateValue(key string, value interface{}) error {
 		return ErrorNodeNotFound
 	}
 	g.nodes[key] = value
+	g.emit(Event{Type: NodeValueUpdated, Key: key, Value: value})
 	return nil
 }
 
-// NewEdge adds an edge between to nodes in the graph
+// NewEdge adds an edge between to nodes in the graph. On graphs created by
+// NewDAG a *CycleError is returned if the edge would create a cycle.
 func (g *DirectedGraph) NewEdge(from, to string) error {
 	g.lock.Lock()
 	defer g.lock.Unlock()
 
+	return g.newEdge(from, to)
+}
+
+// newEdge adds an edge without acquiring the lock
+func (g *DirectedGraph) newEdge(from, to string) error {
 	if _, ok := g.nodes[from]; !ok {
 		return ErrorNodeNotFound
 	}
 	if _, ok := g.nodes[to]; !ok {
 		return ErrorNodeNotFound
 	}
+	if g.edges[from][to] {
+		return nil
+	}
+	if g.order != nil {
+		if err := g.reorder(from, to); err != nil {
+			return err
+		}
+	}
 
 	g.edges[from][to] = true
+	g.reverse[to][from] = true
+	g.emit(Event{Type: EdgeAdded, From: from, To: to})
+	return nil
+}
+
+// DeleteEdge removes the edge between two nodes in the graph
+func (g *DirectedGraph) DeleteEdge(from, to string) error {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	if _, ok := g.nodes[from]; !ok {
+		return ErrorNodeNotFound
+	}
+	if _, ok := g.nodes[to]; !ok {
+		return ErrorNodeNotFound
+	}
+	if !g.edges[from][to] {
+		return ErrorEdgeNotFound
+	}
+
+	delete(g.edges[from], to)
+	delete(g.reverse[to], from)
+	delete(g.capacities[from], to)
+	g.emit(Event{Type: EdgeRemoved, From: from, To: to})
 	return nil
 }
 
@@ -92,6 +197,8 @@ func (g *DirectedGraph) Edges(from string) ([]string, error) {
 	var edges []string
 
 	g.lock.RLock()
+	defer g.lock.RUnlock()
+
 	if _, ok := g.nodes[from]; !ok {
 		return edges, ErrorNodeNotFound
 	}
@@ -100,11 +207,102 @@ func (g *DirectedGraph) Edges(from string) ([]string, error) {
 			edges = append(edges, to)
 		}
 	}
-	g.lock.RUnlock()
 
 	return edges, nil
 }
 
+// Neighbors returns the keys of nodes that the node has an edge pointing to in
+// lexical order
+func (g *DirectedGraph) Neighbors(key string) ([]string, error) {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+
+	if _, ok := g.nodes[key]; !ok {
+		return nil, ErrorNodeNotFound
+	}
+	return sortedKeys(g.edges[key]), nil
+}
+
+// Predecessors returns the keys of nodes that have an edge pointing to the
+// node in lexical order
+func (g *DirectedGraph) Predecessors(key string) ([]string, error) {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+
+	if _, ok := g.nodes[key]; !ok {
+		return nil, ErrorNodeNotFound
+	}
+	return sortedKeys(g.reverse[key]), nil
+}
+
+// HasEdge returns true if there is an edge pointing from one node to another
+func (g *DirectedGraph) HasEdge(from, to string) bool {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+
+	return g.edges[from][to]
+}
+
+// InEdges returns the keys of nodes that have an edge pointing to the node
+func (g *DirectedGraph) InEdges(to string) ([]string, error) {
+	var edges []string
+
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+
+	if _, ok := g.nodes[to]; !ok {
+		return edges, ErrorNodeNotFound
+	}
+	for from := range g.reverse[to] {
+		if g.reverse[to][from] {
+			edges = append(edges, from)
+		}
+	}
+
+	return edges, nil
+}
+
+// InDegree returns the number of edges pointing to the node
+func (g *DirectedGraph) InDegree(key string) (int, error) {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+
+	if _, ok := g.nodes[key]; !ok {
+		return 0, ErrorNodeNotFound
+	}
+	return len(g.reverse[key]), nil
+}
+
+// OutDegree returns the number of edges coming from the node
+func (g *DirectedGraph) OutDegree(key string) (int, error) {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+
+	if _, ok := g.nodes[key]; !ok {
+		return 0, ErrorNodeNotFound
+	}
+	return len(g.edges[key]), nil
+}
+
+// Transpose returns a new graph with the same nodes and values but with all
+// edges reversed. Reversed edges keep their capacities.
+func (g *DirectedGraph) Transpose() *DirectedGraph {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+
+	t := g.copyNodes()
+	for from := range g.edges {
+		for to := range g.edges[from] {
+			t.edges[to][from] = true
+			t.reverse[from][to] = true
+			if capacity, ok := g.capacities[from][to]; ok {
+				t.setCapacity(to, from, capacity)
+			}
+		}
+	}
+	return t
+}
+
 // Nodes returns a list of all nodes in the graph
 func (g *DirectedGraph) Nodes() []string {
 	g.lock.RLock()
@@ -121,22 +319,26 @@ func (g *DirectedGraph) Nodes() []string {
 
 // isCyclicDFS recursively tests nodes for back edges in a depth first way. It
 // expects a `seen` map that it updates and a `rs` (recursive stack) map that it
-// uses to find back edges.
+// uses to find back edges. Nodes that have been seen before but are not on the
+// recursion stack anymore have been fully explored and are skipped.
 func (g *DirectedGraph) isCyclicDFS(seen, rs map[string]bool, key string) bool {
 	seen[key] = true
-	if rs[key] {
-		return true
-	}
 	rs[key] = true
 	for to, active := range g.edges[key] {
-		if active && g.isCyclicDFS(seen, rs, to) {
+		if !active {
+			continue
+		}
+		if rs[to] {
+			return true
+		}
+		if !seen[to] && g.isCyclicDFS(seen, rs, to) {
 			return true
 		}
-		// deactivates the item in the map, which we mis-use as
-		// stack here to improve lookup times. we don't care about the order
-		// when looking for cycles
-		rs[to] = false
 	}
+	// deactivates the item in the map, which we mis-use as stack here to
+	// improve lookup times. we don't care about the order when looking for
+	// cycles
+	rs[key] = false
 	return false
 }
 
@@ -146,6 +348,11 @@ func (g *DirectedGraph) IsCyclic() bool {
 	g.lock.RLock()
 	defer g.lock.RUnlock()
 
+	return g.isCyclic()
+}
+
+// isCyclic tests for cycles without acquiring the lock
+func (g *DirectedGraph) isCyclic() bool {
 	seen := make(map[string]bool)
 	for key := range g.nodes {
 		if seen[key] {
@@ -177,11 +384,20 @@ func (g *DirectedGraph) topSort(seen map[string]bool, order []string, i int, key
 // TopSort returns topological sorted slice of all node keys of the graph. This
 // functions returns a list of all nodes in undefined order if the graph happens
 // to be cyclic. Test with IsCyclic() before using TopSort() if you want to know
-// if there is a valid topological order at all.
+// if there is a valid topological order at all. Graphs created by NewDAG return
+// the topological order they maintain.
 func (g *DirectedGraph) TopSort() []string {
 	g.lock.RLock()
 	defer g.lock.RUnlock()
 
+	if g.order != nil {
+		return g.maintainedOrder()
+	}
+	return g.topOrder()
+}
+
+// topOrder returns the topological order without acquiring the lock
+func (g *DirectedGraph) topOrder() []string {
 	order := make([]string, len(g.nodes))
 	i := len(order) - 1
 
@@ -1,6 +1,7 @@
 package directedgraph
 
 import (
+	"strconv"
 	"testing"
 )
 
@@ -48,6 +49,9 @@ func TestGraphNew(t *testing.T) {
 		if len(g.edges) != 0 {
 			t.Errorf("initial edge list not empty")
 		}
+		if len(g.reverse) != 0 {
+			t.Errorf("initial reverse edge list not empty")
+		}
 	})
 }
 
@@ -61,6 +65,9 @@ func TestGraphNewNode(t *testing.T) {
 		if len(g.edges) != 1 {
 			t.Errorf("unexpected edge list length: %v", len(g.edges))
 		}
+		if len(g.reverse) != 1 {
+			t.Errorf("unexpected reverse edge list length: %v", len(g.reverse))
+		}
 	})
 	t.Run("duplicate nodes", func(t *testing.T) {
 		g := New()
@@ -203,6 +210,266 @@ func TestGraphEdges(t *testing.T) {
 	})
 }
 
+func TestGraphDeleteNode(t *testing.T) {
+	t.Run("existing node", func(t *testing.T) {
+		g := New()
+		for _, nd := range nodes {
+			g.NewNode(nd.key, nd.value)
+		}
+		for _, e := range edges {
+			g.NewEdge(e.from, e.to)
+		}
+		err := g.DeleteNode("eleven")
+		if err != nil {
+			t.Errorf("node `%v`: %v", "eleven", err)
+		}
+		if len(g.nodes) != len(nodes)-1 {
+			t.Errorf("unexpected node list length: %v", len(g.nodes))
+		}
+		if _, ok := g.edges["eleven"]; ok {
+			t.Errorf("edge list of deleted node still present")
+		}
+		if _, ok := g.reverse["eleven"]; ok {
+			t.Errorf("reverse edge list of deleted node still present")
+		}
+		for _, from := range []string{"foo", "friends"} {
+			if g.edges[from]["eleven"] {
+				t.Errorf("dangling edge `%v`->`%v`", from, "eleven")
+			}
+		}
+		if g.reverse["scary"]["eleven"] {
+			t.Errorf("dangling reverse edge `%v`->`%v`", "eleven", "scary")
+		}
+	})
+	t.Run("self-referencing node", func(t *testing.T) {
+		g := New()
+		g.NewNode("foo", nil)
+		g.NewEdge("foo", "foo")
+		err := g.DeleteNode("foo")
+		if err != nil {
+			t.Errorf("node `%v`: %v", "foo", err)
+		}
+		if len(g.nodes) != 0 || len(g.edges) != 0 || len(g.reverse) != 0 {
+			t.Errorf("expected empty graph, got non-empty graph")
+		}
+	})
+	t.Run("unknown node", func(t *testing.T) {
+		g := New()
+		err := g.DeleteNode("foo")
+		if err != ErrorNodeNotFound {
+			t.Errorf("expected `%v` got `%v`", ErrorNodeNotFound, err)
+		}
+	})
+}
+
+func TestGraphDeleteEdge(t *testing.T) {
+	t.Run("existing edge", func(t *testing.T) {
+		g := New()
+		for _, nd := range nodes {
+			g.NewNode(nd.key, nd.value)
+		}
+		for _, e := range edges {
+			g.NewEdge(e.from, e.to)
+		}
+		err := g.DeleteEdge("foo", "eleven")
+		if err != nil {
+			t.Errorf("edge from `%v` to `%v`: %v", "foo", "eleven", err)
+		}
+		if g.edges["foo"]["eleven"] {
+			t.Errorf("edge `%v`->`%v` still present", "foo", "eleven")
+		}
+		if g.reverse["eleven"]["foo"] {
+			t.Errorf("reverse edge `%v`->`%v` still present", "foo", "eleven")
+		}
+		if !g.edges["friends"]["eleven"] {
+			t.Errorf("expected edge `%v`->`%v` not found", "friends", "eleven")
+		}
+	})
+	t.Run("unknown edge", func(t *testing.T) {
+		g := New()
+		g.NewNode("foo", nil)
+		g.NewNode("bar", nil)
+		g.NewEdge("foo", "bar")
+		err := g.DeleteEdge("bar", "foo")
+		if err != ErrorEdgeNotFound {
+			t.Errorf("expected `%v` got `%v`", ErrorEdgeNotFound, err)
+		}
+	})
+	t.Run("unknown nodes", func(t *testing.T) {
+		g := New()
+		g.NewNode("foo", nil)
+		err := g.DeleteEdge("unknown", "foo")
+		if err != ErrorNodeNotFound {
+			t.Errorf("expected `%v` got `%v`", ErrorNodeNotFound, err)
+		}
+		err = g.DeleteEdge("foo", "unknown")
+		if err != ErrorNodeNotFound {
+			t.Errorf("expected `%v` got `%v`", ErrorNodeNotFound, err)
+		}
+	})
+}
+
+func TestGraphInEdges(t *testing.T) {
+	t.Run("existing nodes", func(t *testing.T) {
+		g := New()
+		for _, nd := range nodes {
+			g.NewNode(nd.key, nd.value)
+		}
+		for _, e := range edges {
+			g.NewEdge(e.from, e.to)
+		}
+		for _, e := range edges {
+			from, err := g.InEdges(e.to)
+			if err != nil {
+				t.Errorf("node `%v`: %v", e.to, err)
+			}
+			found := false
+			for i := range from {
+				if from[i] == e.from {
+					found = true
+				}
+			}
+			if !found {
+				t.Errorf("expected edge `%v`->`%v` not found.", e.from, e.to)
+			}
+		}
+	})
+	t.Run("unknown nodes", func(t *testing.T) {
+		g := New()
+		for _, e := range edges {
+			_, err := g.InEdges(e.to)
+			if err != ErrorNodeNotFound {
+				t.Errorf("expected `%v` got `%v`", ErrorNodeNotFound, err)
+			}
+		}
+	})
+}
+
+func TestGraphNeighbors(t *testing.T) {
+	g := diamond()
+	got, err := g.Neighbors("a")
+	if err != nil {
+		t.Errorf("expected `%v` got `%v`", nil, err)
+	}
+	expected := []string{"b", "c", "d"}
+	if !equal(expected, got) {
+		t.Errorf("expected `%v` got `%v`", expected, got)
+	}
+	got, err = g.Predecessors("d")
+	if err != nil {
+		t.Errorf("expected `%v` got `%v`", nil, err)
+	}
+	expected = []string{"a", "b", "c"}
+	if !equal(expected, got) {
+		t.Errorf("expected `%v` got `%v`", expected, got)
+	}
+	if _, err := g.Neighbors("x"); err != ErrorNodeNotFound {
+		t.Errorf("expected `%v` got `%v`", ErrorNodeNotFound, err)
+	}
+	if _, err := g.Predecessors("x"); err != ErrorNodeNotFound {
+		t.Errorf("expected `%v` got `%v`", ErrorNodeNotFound, err)
+	}
+}
+
+func TestGraphHasEdge(t *testing.T) {
+	g := diamond()
+	tt := []struct {
+		from, to string
+		expected bool
+	}{
+		{from: "a", to: "b", expected: true},
+		{from: "b", to: "a", expected: false},
+		{from: "a", to: "x", expected: false},
+		{from: "x", to: "a", expected: false},
+	}
+	for _, tc := range tt {
+		if got := g.HasEdge(tc.from, tc.to); got != tc.expected {
+			t.Errorf("`%v`->`%v`: expected `%v` got `%v`", tc.from, tc.to,
+				tc.expected, got)
+		}
+	}
+}
+
+func TestGraphDegree(t *testing.T) {
+	t.Run("existing nodes", func(t *testing.T) {
+		g := New()
+		for _, nd := range nodes {
+			g.NewNode(nd.key, nd.value)
+		}
+		for _, e := range edges {
+			g.NewEdge(e.from, e.to)
+		}
+		tt := []struct {
+			key     string
+			in, out int
+		}{
+			{key: "foo", in: 0, out: 1},
+			{key: "eleven", in: 2, out: 1},
+			{key: "friends", in: 0, out: 1},
+			{key: "scary", in: 1, out: 0},
+			{key: "ocean's", in: 0, out: 0},
+		}
+		for _, tc := range tt {
+			in, err := g.InDegree(tc.key)
+			if err != nil {
+				t.Errorf("node `%v`: %v", tc.key, err)
+			}
+			if in != tc.in {
+				t.Errorf("node `%v`: expected in-degree `%v` got `%v`", tc.key, tc.in, in)
+			}
+			out, err := g.OutDegree(tc.key)
+			if err != nil {
+				t.Errorf("node `%v`: %v", tc.key, err)
+			}
+			if out != tc.out {
+				t.Errorf("node `%v`: expected out-degree `%v` got `%v`", tc.key, tc.out, out)
+			}
+		}
+	})
+	t.Run("unknown node", func(t *testing.T) {
+		g := New()
+		_, err := g.InDegree("foo")
+		if err != ErrorNodeNotFound {
+			t.Errorf("expected `%v` got `%v`", ErrorNodeNotFound, err)
+		}
+		_, err = g.OutDegree("foo")
+		if err != ErrorNodeNotFound {
+			t.Errorf("expected `%v` got `%v`", ErrorNodeNotFound, err)
+		}
+	})
+}
+
+func TestGraphTranspose(t *testing.T) {
+	g := New()
+	for _, nd := range nodes {
+		g.NewNode(nd.key, nd.value)
+	}
+	for _, e := range edges {
+		g.NewEdge(e.from, e.to)
+	}
+	tg := g.Transpose()
+	if len(tg.nodes) != len(nodes) {
+		t.Errorf("unexpected node list length: %v", len(tg.nodes))
+	}
+	for _, nd := range nodes {
+		if value := tg.nodes[nd.key]; value != nd.value {
+			t.Errorf("expected node value `%v`, got `%v`", nd.value, value)
+		}
+	}
+	for _, e := range edges {
+		if !tg.edges[e.to][e.from] || !tg.reverse[e.from][e.to] {
+			t.Errorf("expected edge `%v`->`%v` not found.", e.to, e.from)
+		}
+		if tg.edges[e.from][e.to] {
+			t.Errorf("unexpected edge `%v`->`%v` found.", e.from, e.to)
+		}
+	}
+	// original graph must not be altered
+	if !g.edges["foo"]["eleven"] {
+		t.Errorf("expected edge `%v`->`%v` not found.", "foo", "eleven")
+	}
+}
+
 func TestGraphNodes(t *testing.T) {
 	t.Run("empty graph", func(t *testing.T) {
 		g := New()
@@ -264,6 +531,29 @@ func TestGraphIsCyclic(t *testing.T) {
 			t.Errorf("expected `false` got `%v`", got)
 		}
 	})
+	t.Run("acyclic graph with many paths", func(t *testing.T) {
+		// a chain of diamonds has 2^n paths from the first to the last node,
+		// fully explored nodes must not be explored again
+		g := New()
+		g.NewNode("0", nil)
+		for i := 1; i <= 100; i++ {
+			prev, key := strconv.Itoa(i-1), strconv.Itoa(i)
+			g.NewNode(key+"l", nil)
+			g.NewNode(key+"r", nil)
+			g.NewNode(key, nil)
+			g.NewEdge(prev, key+"l")
+			g.NewEdge(prev, key+"r")
+			g.NewEdge(key+"l", key)
+			g.NewEdge(key+"r", key)
+		}
+		if got := g.IsCyclic(); got {
+			t.Errorf("expected `false` got `%v`", got)
+		}
+		g.NewEdge("100", "0")
+		if got := g.IsCyclic(); !got {
+			t.Errorf("expected `true` got `%v`", got)
+		}
+	})
 	t.Run("cyclic graph (back edge)", func(t *testing.T) {
 		g := New()
 		for _, nd := range nodes {
@@ -364,3 +654,15 @@ func equal(a, b []string) bool {
 	}
 	return true
 }
+
+func TestGraphEmptyKeyNeighbors(t *testing.T) {
+	g := emptyKey()
+	neighbors, _ := g.Neighbors("a")
+	if expected := []string{""}; !equal(expected, neighbors) {
+		t.Errorf("expected `%v` got `%v`", expected, neighbors)
+	}
+	predecessors, _ := g.Predecessors("b")
+	if expected := []string{""}; !equal(expected, predecessors) {
+		t.Errorf("expected `%v` got `%v`", expected, predecessors)
+	}
+}
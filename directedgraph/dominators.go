@@ -0,0 +1,182 @@
+package directedgraph
+
+import "sort"
+
+// DominatorTree holds the dominance relation of all nodes reachable from a
+// root node. A node dominates another node if every path from the root to the
+// other node passes through it.
+type DominatorTree struct {
+	// Root is the key of the root node
+	Root string
+	// Idom holds the immediate dominator of every reachable node except the
+	// root
+	Idom map[string]string
+	// Frontier holds the dominance frontier of every reachable node in lexical
+	// order. The dominance frontier of a node is the set of nodes where its
+	// dominance ends.
+	Frontier map[string][]string
+}
+
+// Dominates returns true if node a dominates node b. Every reachable node
+// dominates itself.
+func (d *DominatorTree) Dominates(a, b string) bool {
+	if _, ok := d.Frontier[b]; !ok {
+		return false
+	}
+	for ; b != d.Root; b = d.Idom[b] {
+		if a == b {
+			return true
+		}
+	}
+	return a == d.Root
+}
+
+// postOrder recursively numbers all nodes reachable from key in post order
+func (g *DirectedGraph) postOrder(number map[string]int, order []string, key string) []string {
+	number[key] = -1
+	for _, to := range sortedKeys(g.edges[key]) {
+		if _, seen := number[to]; !seen {
+			order = g.postOrder(number, order, to)
+		}
+	}
+	number[key] = len(order)
+	return append(order, key)
+}
+
+// Dominators computes the dominator tree and the dominance frontiers of all
+// nodes reachable from the root node using the iterative algorithm by Cooper,
+// Harvey and Kennedy. Nodes that cannot be reached from the root are not part
+// of the result.
+func (g *DirectedGraph) Dominators(root string) (*DominatorTree, error) {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+
+	if _, ok := g.nodes[root]; !ok {
+		return nil, ErrorNodeNotFound
+	}
+	number := make(map[string]int)
+	order := g.postOrder(number, nil, root)
+
+	// idom is indexed by post order number, the root has the highest number
+	rootNumber := len(order) - 1
+	idom := make([]int, len(order))
+	for i := range idom {
+		idom[i] = -1
+	}
+	idom[rootNumber] = rootNumber
+	intersect := func(a, b int) int {
+		for a != b {
+			for a < b {
+				a = idom[a]
+			}
+			for b < a {
+				b = idom[b]
+			}
+		}
+		return a
+	}
+	for changed := true; changed; {
+		changed = false
+		// walk in reverse post order, skipping the root
+		for i := rootNumber - 1; i >= 0; i-- {
+			newIdom := -1
+			for from := range g.reverse[order[i]] {
+				p, reachable := number[from]
+				if !reachable || idom[p] < 0 {
+					continue
+				}
+				if newIdom < 0 {
+					newIdom = p
+				} else {
+					newIdom = intersect(p, newIdom)
+				}
+			}
+			if idom[i] != newIdom {
+				idom[i] = newIdom
+				changed = true
+			}
+		}
+	}
+
+	d := &DominatorTree{
+		Root:     root,
+		Idom:     make(map[string]string, len(order)),
+		Frontier: make(map[string][]string, len(order)),
+	}
+	frontier := make([]map[string]bool, len(order))
+	for i, key := range order {
+		frontier[i] = make(map[string]bool)
+		if i != rootNumber {
+			d.Idom[key] = order[idom[i]]
+		}
+	}
+	for i, key := range order {
+		var preds []int
+		for from := range g.reverse[key] {
+			if p, reachable := number[from]; reachable {
+				preds = append(preds, p)
+			}
+		}
+		if len(preds) < 2 {
+			continue
+		}
+		for _, runner := range preds {
+			for runner != idom[i] {
+				frontier[runner][key] = true
+				runner = idom[runner]
+			}
+		}
+	}
+	for i, key := range order {
+		d.Frontier[key] = sortedKeys(frontier[i])
+	}
+	return d, nil
+}
+
+// LowestCommonAncestors returns the lowest common ancestors of two nodes in
+// lexical order. A common ancestor is a node from which both nodes can be
+// reached, where every node counts as its own ancestor. It is lowest, if none
+// of its descendants is a common ancestor as well. In a tree there is exactly
+// one lowest common ancestor, in an acyclic graph there may be several or
+// none. ErrorGraphIsCyclic is returned if the graph is not acyclic.
+func (g *DirectedGraph) LowestCommonAncestors(a, b string) ([]string, error) {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+
+	if _, ok := g.nodes[a]; !ok {
+		return nil, ErrorNodeNotFound
+	}
+	if _, ok := g.nodes[b]; !ok {
+		return nil, ErrorNodeNotFound
+	}
+	if g.order == nil && g.isCyclic() {
+		return nil, ErrorGraphIsCyclic
+	}
+
+	ancestorsA := g.ancestorSet(a)
+	ancestorsB := g.ancestorSet(b)
+	common := make(map[string]bool)
+	for key := range ancestorsA {
+		if ancestorsB[key] {
+			common[key] = true
+		}
+	}
+
+	// a common ancestor having a descendant that is a common ancestor as well
+	// also has a direct successor that is a common ancestor
+	lowest := []string{}
+	for key := range common {
+		isLowest := true
+		for to := range g.edges[key] {
+			if common[to] {
+				isLowest = false
+				break
+			}
+		}
+		if isLowest {
+			lowest = append(lowest, key)
+		}
+	}
+	sort.Strings(lowest)
+	return lowest, nil
+}
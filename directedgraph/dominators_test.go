@@ -0,0 +1,174 @@
+package directedgraph
+
+import (
+	"testing"
+)
+
+// flowGraph returns a control flow graph with a loop `2`->`3`/`4`->`5`->`2`
+// and an unreachable node `7`
+func flowGraph() *DirectedGraph {
+	g := New()
+	for _, key := range []string{"1", "2", "3", "4", "5", "6", "7"} {
+		g.NewNode(key, nil)
+	}
+	for _, e := range [][2]string{{"1", "2"}, {"2", "3"}, {"2", "4"}, {"2", "6"},
+		{"3", "5"}, {"4", "5"}, {"5", "2"}, {"7", "6"}} {
+		g.NewEdge(e[0], e[1])
+	}
+	return g
+}
+
+func TestGraphDominators(t *testing.T) {
+	t.Run("control flow graph", func(t *testing.T) {
+		d, err := flowGraph().Dominators("1")
+		if err != nil {
+			t.Fatalf("expected `%v` got `%v`", nil, err)
+		}
+		idom := map[string]string{"2": "1", "3": "2", "4": "2", "5": "2",
+			"6": "2"}
+		if len(d.Idom) != len(idom) {
+			t.Errorf("expected `%v` immediate dominators got `%v`", len(idom),
+				len(d.Idom))
+		}
+		for key, expected := range idom {
+			if got := d.Idom[key]; got != expected {
+				t.Errorf("node `%v`: expected idom `%v` got `%v`", key, expected, got)
+			}
+		}
+		frontier := map[string][]string{"1": {}, "2": {"2"}, "3": {"5"},
+			"4": {"5"}, "5": {"2"}, "6": {}}
+		if len(d.Frontier) != len(frontier) {
+			t.Errorf("expected `%v` frontiers got `%v`", len(frontier),
+				len(d.Frontier))
+		}
+		for key, expected := range frontier {
+			if got := d.Frontier[key]; !equal(expected, got) {
+				t.Errorf("node `%v`: expected frontier `%v` got `%v`", key,
+					expected, got)
+			}
+		}
+	})
+	t.Run("diamond", func(t *testing.T) {
+		d, err := diamond().Dominators("a")
+		if err != nil {
+			t.Fatalf("expected `%v` got `%v`", nil, err)
+		}
+		idom := map[string]string{"b": "a", "c": "a", "d": "a", "e": "d"}
+		for key, expected := range idom {
+			if got := d.Idom[key]; got != expected {
+				t.Errorf("node `%v`: expected idom `%v` got `%v`", key, expected, got)
+			}
+		}
+		for _, key := range []string{"b", "c"} {
+			if got := d.Frontier[key]; !equal([]string{"d"}, got) {
+				t.Errorf("node `%v`: expected frontier `%v` got `%v`", key,
+					[]string{"d"}, got)
+			}
+		}
+	})
+	t.Run("empty key", func(t *testing.T) {
+		g := New()
+		for _, key := range []string{"a", "x", "y", ""} {
+			g.NewNode(key, nil)
+		}
+		for _, e := range [][2]string{{"a", "x"}, {"a", "y"}, {"x", ""}, {"y", ""}} {
+			g.NewEdge(e[0], e[1])
+		}
+		d, err := g.Dominators("a")
+		if err != nil {
+			t.Fatalf("expected `%v` got `%v`", nil, err)
+		}
+		if got, ok := d.Idom[""]; !ok || got != "a" {
+			t.Errorf("expected idom `%v` got `%v`", "a", got)
+		}
+		for _, key := range []string{"x", "y"} {
+			if expected, got := []string{""}, d.Frontier[key]; !equal(expected, got) {
+				t.Errorf("node `%v`: expected frontier `%v` got `%v`", key, expected, got)
+			}
+		}
+	})
+	t.Run("unknown root", func(t *testing.T) {
+		_, err := New().Dominators("foo")
+		if err != ErrorNodeNotFound {
+			t.Errorf("expected `%v` got `%v`", ErrorNodeNotFound, err)
+		}
+	})
+}
+
+func TestDominatorTreeDominates(t *testing.T) {
+	d, _ := flowGraph().Dominators("1")
+	tt := []struct {
+		a, b     string
+		expected bool
+	}{
+		{a: "1", b: "6", expected: true},
+		{a: "2", b: "5", expected: true},
+		{a: "5", b: "5", expected: true},
+		{a: "3", b: "5", expected: false},
+		{a: "6", b: "2", expected: false},
+		{a: "1", b: "7", expected: false},
+		{a: "7", b: "6", expected: false},
+	}
+	for _, tc := range tt {
+		if got := d.Dominates(tc.a, tc.b); got != tc.expected {
+			t.Errorf("`%v` dominates `%v`: expected `%v` got `%v`", tc.a, tc.b,
+				tc.expected, got)
+		}
+	}
+}
+
+func TestGraphLowestCommonAncestors(t *testing.T) {
+	t.Run("acyclic graph", func(t *testing.T) {
+		g := diamond()
+		g.NewNode("g", nil)
+		g.NewEdge("b", "g")
+		g.NewEdge("c", "g")
+		tt := []struct {
+			a, b     string
+			expected []string
+		}{
+			{a: "d", b: "g", expected: []string{"b", "c"}},
+			{a: "b", b: "c", expected: []string{"a"}},
+			{a: "e", b: "d", expected: []string{"d"}},
+			{a: "e", b: "e", expected: []string{"e"}},
+			{a: "e", b: "f", expected: []string{}},
+		}
+		for _, tc := range tt {
+			got, err := g.LowestCommonAncestors(tc.a, tc.b)
+			if err != nil {
+				t.Errorf("expected `%v` got `%v`", nil, err)
+			}
+			if !equal(tc.expected, got) {
+				t.Errorf("`%v` and `%v`: expected `%v` got `%v`", tc.a, tc.b,
+					tc.expected, got)
+			}
+		}
+	})
+	t.Run("empty key", func(t *testing.T) {
+		g := emptyKey()
+		g.NewNode("c", nil)
+		g.NewEdge("", "c")
+		got, err := g.LowestCommonAncestors("b", "c")
+		if err != nil {
+			t.Errorf("expected `%v` got `%v`", nil, err)
+		}
+		if expected := []string{""}; !equal(expected, got) {
+			t.Errorf("expected `%v` got `%v`", expected, got)
+		}
+	})
+	t.Run("cyclic graph", func(t *testing.T) {
+		_, err := flowGraph().LowestCommonAncestors("3", "4")
+		if err != ErrorGraphIsCyclic {
+			t.Errorf("expected `%v` got `%v`", ErrorGraphIsCyclic, err)
+		}
+	})
+	t.Run("unknown nodes", func(t *testing.T) {
+		g := diamond()
+		if _, err := g.LowestCommonAncestors("a", "x"); err != ErrorNodeNotFound {
+			t.Errorf("expected `%v` got `%v`", ErrorNodeNotFound, err)
+		}
+		if _, err := g.LowestCommonAncestors("x", "a"); err != ErrorNodeNotFound {
+			t.Errorf("expected `%v` got `%v`", ErrorNodeNotFound, err)
+		}
+	})
+}
@@ -0,0 +1,130 @@
+package directedgraph
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+
+	"github.com/danrl/golibby/internal/dot"
+)
+
+// DOTOptions controls how a graph is written in Graphviz DOT format
+type DOTOptions struct {
+	// Name is the name of the graph, the graph is anonymous if empty
+	Name string
+	// Label returns the label of a node. Nodes are written without label if
+	// Label is nil. ValueLabel uses node values as labels.
+	Label func(key string, value interface{}) string
+	// Weight returns the weight of an edge and whether the edge has a weight
+	// at all. Weights are written as edge labels.
+	Weight func(from, to string) (float64, bool)
+	// Highlight is a path of node keys. Nodes and edges along the path are
+	// drawn in red.
+	Highlight []string
+}
+
+// ValueLabel is a label function for DOTOptions that labels nodes with their
+// values
+func ValueLabel(key string, value interface{}) string {
+	return fmt.Sprintf("%v", value)
+}
+
+// sortedNodes returns all node keys in lexical order
+func (g *DirectedGraph) sortedNodes() []string {
+	keys := make([]string, 0, len(g.nodes))
+	for key := range g.nodes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// WriteDOT writes the graph in Graphviz DOT format. Nodes and edges are written
+// in lexical order.
+func (g *DirectedGraph) WriteDOT(w io.Writer, opts DOTOptions) error {
+	onPath := make(map[string]bool)
+	pathEdges := make(map[[2]string]bool)
+	for i, key := range opts.Highlight {
+		onPath[key] = true
+		if i > 0 {
+			pathEdges[[2]string{opts.Highlight[i-1], key}] = true
+		}
+	}
+
+	// callbacks may call methods of the graph, so they run on a copy taken
+	// under the lock
+	g.lock.RLock()
+	keys := g.sortedNodes()
+	values := make([]interface{}, len(keys))
+	var edges [][2]string
+	for i, from := range keys {
+		values[i] = g.nodes[from]
+		for _, to := range sortedKeys(g.edges[from]) {
+			edges = append(edges, [2]string{from, to})
+		}
+	}
+	g.lock.RUnlock()
+
+	var out bytes.Buffer
+	out.WriteString("digraph ")
+	if opts.Name != "" {
+		out.WriteString(dot.Quote(opts.Name) + " ")
+	}
+	out.WriteString("{\n")
+	for i, key := range keys {
+		var attrs []string
+		if opts.Label != nil {
+			attrs = append(attrs, "label", opts.Label(key, values[i]))
+		}
+		if onPath[key] {
+			attrs = append(attrs, "color", "red")
+		}
+		out.WriteString(fmt.Sprintf("\t%s%s;\n", dot.Quote(key), dot.Attrs(attrs...)))
+	}
+	for _, e := range edges {
+		var attrs []string
+		if opts.Weight != nil {
+			if weight, ok := opts.Weight(e[0], e[1]); ok {
+				attrs = append(attrs, "label",
+					strconv.FormatFloat(weight, 'g', -1, 64))
+			}
+		}
+		if pathEdges[e] {
+			attrs = append(attrs, "color", "red")
+		}
+		out.WriteString(fmt.Sprintf("\t%s -> %s%s;\n", dot.Quote(e[0]),
+			dot.Quote(e[1]), dot.Attrs(attrs...)))
+	}
+	out.WriteString("}\n")
+
+	_, err := out.WriteTo(w)
+	return err
+}
+
+// ReadDOT reads a directed graph in Graphviz DOT format. Nodes with a `label`
+// attribute get the label as value, all other nodes have a nil value. All other
+// attributes are ignored. ErrorInvalidFormat is returned if the input is not a
+// valid DOT document describing a directed graph.
+func ReadDOT(r io.Reader) (*DirectedGraph, error) {
+	d, err := dot.Parse(r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrorInvalidFormat, err)
+	}
+	if !d.Directed {
+		return nil, fmt.Errorf("%w: graph is undirected", ErrorInvalidFormat)
+	}
+	g := New()
+	for _, nd := range d.Nodes {
+		var value interface{}
+		if label, ok := nd.Attrs["label"]; ok {
+			value = label
+		}
+		g.NewNode(nd.ID, value)
+	}
+	for _, e := range d.Edges {
+		g.NewEdge(e.From, e.To)
+	}
+	return g, nil
+}
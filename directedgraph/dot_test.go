@@ -0,0 +1,149 @@
+package directedgraph
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestGraphWriteDOT(t *testing.T) {
+	t.Run("callbacks using the graph", func(t *testing.T) {
+		g := New()
+		g.NewNode("a", "foo")
+		g.NewNode("b", "bar")
+		g.NewEdge("a", "b")
+		var out bytes.Buffer
+		err := g.WriteDOT(&out, DOTOptions{
+			Label: func(key string, _ interface{}) string {
+				// a writer waiting for the lock must not block readers
+				// called from within the callback
+				done := make(chan struct{})
+				go func() {
+					g.UpdateValue(key, key)
+					close(done)
+				}()
+				<-done
+				value, _ := g.Value(key)
+				return value.(string)
+			},
+		})
+		if err != nil {
+			t.Errorf("expected `%v` got `%v`", nil, err)
+		}
+		if got := out.String(); !strings.Contains(got, `"a" [label="a"]`) {
+			t.Errorf("unexpected output `%v`", got)
+		}
+	})
+	t.Run("empty graph", func(t *testing.T) {
+		var out bytes.Buffer
+		err := New().WriteDOT(&out, DOTOptions{})
+		if err != nil {
+			t.Errorf("expected `%v` got `%v`", nil, err)
+		}
+		expected := "digraph {\n}\n"
+		if got := out.String(); got != expected {
+			t.Errorf("expected `%v` got `%v`", expected, got)
+		}
+	})
+	t.Run("empty key", func(t *testing.T) {
+		var out bytes.Buffer
+		if err := emptyKey().WriteDOT(&out, DOTOptions{}); err != nil {
+			t.Errorf("expected `%v` got `%v`", nil, err)
+		}
+		expected := `digraph {
+	"";
+	"a";
+	"b";
+	"" -> "b";
+	"a" -> "";
+}
+`
+		if got := out.String(); got != expected {
+			t.Errorf("expected `%v` got `%v`", expected, got)
+		}
+	})
+	t.Run("regular graph", func(t *testing.T) {
+		g := New()
+		for _, nd := range nodes {
+			g.NewNode(nd.key, nd.value)
+		}
+		for _, e := range edges {
+			g.NewEdge(e.from, e.to)
+		}
+		var out bytes.Buffer
+		err := g.WriteDOT(&out, DOTOptions{
+			Name:  "test",
+			Label: ValueLabel,
+			Weight: func(from, to string) (float64, bool) {
+				return 1.5, from == "foo"
+			},
+			Highlight: []string{"friends", "eleven", "scary"},
+		})
+		if err != nil {
+			t.Errorf("expected `%v` got `%v`", nil, err)
+		}
+		expected := `digraph "test" {
+	"eleven" [label="11", color="red"];
+	"foo" [label="bar"];
+	"friends" [label="🤩", color="red"];
+	"ocean's" [label="11!!!"];
+	"scary" [label="1337", color="red"];
+	"eleven" -> "scary" [color="red"];
+	"foo" -> "eleven" [label="1.5"];
+	"friends" -> "eleven" [color="red"];
+}
+`
+		if got := out.String(); got != expected {
+			t.Errorf("expected `%v` got `%v`", expected, got)
+		}
+	})
+}
+
+func TestReadDOT(t *testing.T) {
+	t.Run("round trip", func(t *testing.T) {
+		g := New()
+		for _, nd := range nodes {
+			g.NewNode(nd.key, nd.value)
+		}
+		for _, e := range edges {
+			g.NewEdge(e.from, e.to)
+		}
+		var out bytes.Buffer
+		g.WriteDOT(&out, DOTOptions{Label: ValueLabel})
+		r, err := ReadDOT(&out)
+		if err != nil {
+			t.Fatalf("expected `%v` got `%v`", nil, err)
+		}
+		if len(r.nodes) != len(nodes) {
+			t.Errorf("expected `%v` nodes, got `%v`", len(nodes), len(r.nodes))
+		}
+		for _, nd := range nodes {
+			if value := r.nodes[nd.key]; value != ValueLabel(nd.key, nd.value) {
+				t.Errorf("expected node value `%v`, got `%v`", nd.value, value)
+			}
+		}
+		for _, e := range edges {
+			if !r.edges[e.from][e.to] || !r.reverse[e.to][e.from] {
+				t.Errorf("expected edge `%v`->`%v` not found.", e.from, e.to)
+			}
+		}
+	})
+	t.Run("nodes without label", func(t *testing.T) {
+		r, err := ReadDOT(strings.NewReader(`digraph { a -> b }`))
+		if err != nil {
+			t.Fatalf("expected `%v` got `%v`", nil, err)
+		}
+		if value, ok := r.nodes["a"]; !ok || value != nil {
+			t.Errorf("expected node `%v` with value `%v`", "a", nil)
+		}
+	})
+	t.Run("invalid input", func(t *testing.T) {
+		for _, in := range []string{`digraph {`, `graph { a -- b }`} {
+			_, err := ReadDOT(strings.NewReader(in))
+			if !errors.Is(err, ErrorInvalidFormat) {
+				t.Errorf("expected `%v` got `%v`", ErrorInvalidFormat, err)
+			}
+		}
+	})
+}
@@ -0,0 +1,35 @@
+package directedgraph
+
+import (
+	"github.com/danrl/golibby/graphalg"
+)
+
+// EulerianCircuit returns a closed walk that follows every edge exactly once.
+// The returned error wraps graphalg.ErrorNotEulerian and explains why there is
+// no such walk. See graphalg.DirectedEulerianCircuit.
+func (g *DirectedGraph) EulerianCircuit() ([]string, error) {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+
+	return graphalg.DirectedEulerianCircuit(view{g: g})
+}
+
+// EulerianPath returns a walk that follows every edge exactly once. The
+// returned error wraps graphalg.ErrorNotEulerian and explains why there is no
+// such walk. See graphalg.DirectedEulerianPath.
+func (g *DirectedGraph) EulerianPath() ([]string, error) {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+
+	return graphalg.DirectedEulerianPath(view{g: g})
+}
+
+// HamiltonianPath returns a path along the edges that visits every node
+// exactly once. Large graphs are searched for at most budget steps. See
+// graphalg.HamiltonianPath.
+func (g *DirectedGraph) HamiltonianPath(budget int) ([]string, error) {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+
+	return graphalg.HamiltonianPath(view{g: g}, budget)
+}
@@ -0,0 +1,70 @@
+package directedgraph
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/danrl/golibby/graphalg"
+)
+
+func TestGraphEulerianCircuit(t *testing.T) {
+	g := New()
+	for _, key := range []string{"a", "b", "c"} {
+		g.NewNode(key, nil)
+	}
+	g.NewEdge("a", "b")
+	g.NewEdge("b", "c")
+	g.NewEdge("c", "a")
+	got, err := g.EulerianCircuit()
+	if err != nil {
+		t.Errorf("expected `%v` got `%v`", nil, err)
+	}
+	expected := []string{"a", "b", "c", "a"}
+	if !equal(expected, got) {
+		t.Errorf("expected `%v` got `%v`", expected, got)
+	}
+	g.DeleteEdge("c", "a")
+	if _, err := g.EulerianCircuit(); !errors.Is(err, graphalg.ErrorNotEulerian) {
+		t.Errorf("expected `%v` got `%v`", graphalg.ErrorNotEulerian, err)
+	}
+}
+
+func TestGraphEulerianPath(t *testing.T) {
+	got, err := diamond().EulerianPath()
+	if !errors.Is(err, graphalg.ErrorNotEulerian) {
+		t.Errorf("expected `%v` got `%v`", graphalg.ErrorNotEulerian, err)
+	}
+	g := New()
+	for _, key := range []string{"a", "b", "c"} {
+		g.NewNode(key, nil)
+	}
+	g.NewEdge("b", "a")
+	g.NewEdge("a", "b")
+	g.NewEdge("b", "c")
+	got, err = g.EulerianPath()
+	if err != nil {
+		t.Errorf("expected `%v` got `%v`", nil, err)
+	}
+	expected := []string{"b", "a", "b", "c"}
+	if !equal(expected, got) {
+		t.Errorf("expected `%v` got `%v`", expected, got)
+	}
+}
+
+func TestGraphHamiltonianPath(t *testing.T) {
+	got, err := diamond().HamiltonianPath(0)
+	if err != graphalg.ErrorNotHamiltonian {
+		t.Errorf("expected `%v` got `%v`", graphalg.ErrorNotHamiltonian, err)
+	}
+	g := diamond()
+	g.NewEdge("b", "c")
+	g.NewEdge("e", "f")
+	got, err = g.HamiltonianPath(0)
+	if err != nil {
+		t.Errorf("expected `%v` got `%v`", nil, err)
+	}
+	expected := []string{"a", "b", "c", "d", "e", "f"}
+	if !equal(expected, got) {
+		t.Errorf("expected `%v` got `%v`", expected, got)
+	}
+}
@@ -0,0 +1,204 @@
+package directedgraph
+
+import (
+	"sync/atomic"
+)
+
+// EventType identifies the kind of mutation an event describes
+type EventType int
+
+const (
+	// NodeAdded is emitted when a node has been added to the graph
+	NodeAdded EventType = iota
+	// NodeRemoved is emitted when a node has been removed from the graph. It
+	// is preceded by EdgeRemoved events for all edges of the node.
+	NodeRemoved
+	// NodeValueUpdated is emitted when the value of a node has been updated
+	NodeValueUpdated
+	// EdgeAdded is emitted when an edge has been added to the graph
+	EdgeAdded
+	// EdgeRemoved is emitted when an edge has been removed from the graph
+	EdgeRemoved
+)
+
+func (t EventType) String() string {
+	switch t {
+	case NodeAdded:
+		return "NodeAdded"
+	case NodeRemoved:
+		return "NodeRemoved"
+	case NodeValueUpdated:
+		return "NodeValueUpdated"
+	case EdgeAdded:
+		return "EdgeAdded"
+	case EdgeRemoved:
+		return "EdgeRemoved"
+	}
+	return "Unknown"
+}
+
+// Event describes a single mutation of the graph
+type Event struct {
+	Type EventType
+	// Key is the key of the affected node in node events
+	Key string
+	// Value is the new value of the node in NodeAdded and NodeValueUpdated
+	// events
+	Value interface{}
+	// From and To identify the affected edge in edge events
+	From, To string
+	// Sequence numbers all events of a graph in mutation order, starting at 1.
+	// Gaps in the sequence seen by a subscriber indicate dropped events.
+	Sequence uint64
+}
+
+// DropPolicy decides which events are discarded when the buffer of a
+// subscription is full
+type DropPolicy int
+
+const (
+	// DropNewest discards the event that does not fit into the buffer
+	DropNewest DropPolicy = iota
+	// DropOldest discards the oldest buffered event to make room for the new
+	// event
+	DropOldest
+)
+
+// DefaultBuffer is the number of events buffered for a subscription if no
+// buffer size is given
+const DefaultBuffer = 64
+
+// SubscribeOptions configures a subscription
+type SubscribeOptions struct {
+	// Buffer is the number of events buffered for the subscriber. Values
+	// smaller than 1 select DefaultBuffer.
+	Buffer int
+	// Policy decides which events are dropped if the buffer is full
+	Policy DropPolicy
+}
+
+// Subscription delivers events about mutations of a graph. Events are
+// delivered in mutation order. Mutations never wait for a subscriber, events
+// are dropped according to the drop policy instead if a subscriber falls
+// behind.
+type Subscription struct {
+	graph   *DirectedGraph
+	events  chan Event
+	policy  DropPolicy
+	dropped uint64
+}
+
+// Subscribe registers a new subscription for mutation events
+func (g *DirectedGraph) Subscribe(opts SubscribeOptions) *Subscription {
+	if opts.Buffer < 1 {
+		opts.Buffer = DefaultBuffer
+	}
+	s := &Subscription{
+		graph:  g,
+		events: make(chan Event, opts.Buffer),
+		policy: opts.Policy,
+	}
+
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	g.subscribers = append(g.subscribers, s)
+	return s
+}
+
+// Events returns the channel events are delivered on. The channel is closed
+// when the subscription is closed.
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+// Dropped returns the number of events that have been dropped so far
+func (s *Subscription) Dropped() uint64 {
+	return atomic.LoadUint64(&s.dropped)
+}
+
+// Close ends the subscription and closes the events channel. Buffered events
+// can still be received after closing. Closing a subscription more than once
+// has no effect.
+func (s *Subscription) Close() {
+	g := s.graph
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	for i := range g.subscribers {
+		if g.subscribers[i] == s {
+			g.subscribers = append(g.subscribers[:i], g.subscribers[i+1:]...)
+			close(s.events)
+			return
+		}
+	}
+}
+
+// deliver hands an event to the subscriber without blocking
+func (s *Subscription) deliver(e Event) {
+	for {
+		select {
+		case s.events <- e:
+			return
+		default:
+		}
+		if s.policy == DropNewest {
+			atomic.AddUint64(&s.dropped, 1)
+			return
+		}
+		// the subscriber may have made room in the meantime, in which case
+		// nothing is dropped
+		select {
+		case <-s.events:
+			atomic.AddUint64(&s.dropped, 1)
+		default:
+		}
+	}
+}
+
+// emit numbers an event and delivers it to all subscribers. It has to be
+// called while holding the write lock, which guarantees mutation order.
+func (g *DirectedGraph) emit(e Event) {
+	g.sequence++
+	if len(g.subscribers) == 0 {
+		return
+	}
+	e.Sequence = g.sequence
+	for _, s := range g.subscribers {
+		s.deliver(e)
+	}
+}
+
+// emitRemoved emits events as if all edges and then all nodes of the graph
+// were removed, in lexical order
+func (g *DirectedGraph) emitRemoved() {
+	if len(g.subscribers) == 0 {
+		return
+	}
+	keys := g.sortedNodes()
+	for _, from := range keys {
+		for _, to := range sortedKeys(g.edges[from]) {
+			g.emit(Event{Type: EdgeRemoved, From: from, To: to})
+		}
+	}
+	for _, key := range keys {
+		g.emit(Event{Type: NodeRemoved, Key: key})
+	}
+}
+
+// emitAdded emits events as if all nodes and then all edges of the graph were
+// added, in lexical order
+func (g *DirectedGraph) emitAdded() {
+	if len(g.subscribers) == 0 {
+		return
+	}
+	keys := g.sortedNodes()
+	for _, key := range keys {
+		g.emit(Event{Type: NodeAdded, Key: key, Value: g.nodes[key]})
+	}
+	for _, from := range keys {
+		for _, to := range sortedKeys(g.edges[from]) {
+			g.emit(Event{Type: EdgeAdded, From: from, To: to})
+		}
+	}
+}
@@ -0,0 +1,209 @@
+package directedgraph
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+)
+
+// receive reads all currently buffered events from a subscription
+func receive(s *Subscription) []Event {
+	var events []Event
+	for {
+		select {
+		case e := <-s.Events():
+			events = append(events, e)
+		default:
+			return events
+		}
+	}
+}
+
+func TestEventTypeString(t *testing.T) {
+	tt := []struct {
+		in       EventType
+		expected string
+	}{
+		{in: NodeAdded, expected: "NodeAdded"},
+		{in: NodeRemoved, expected: "NodeRemoved"},
+		{in: NodeValueUpdated, expected: "NodeValueUpdated"},
+		{in: EdgeAdded, expected: "EdgeAdded"},
+		{in: EdgeRemoved, expected: "EdgeRemoved"},
+		{in: EventType(42), expected: "Unknown"},
+	}
+	for _, tc := range tt {
+		if got := tc.in.String(); got != tc.expected {
+			t.Errorf("expected `%v` got `%v`", tc.expected, got)
+		}
+	}
+}
+
+func TestGraphSubscribe(t *testing.T) {
+	t.Run("mutation order", func(t *testing.T) {
+		g := New()
+		s := g.Subscribe(SubscribeOptions{})
+		defer s.Close()
+		g.NewNode("a", 1)
+		g.NewNode("b", 2)
+		g.NewNode("a", 3) // fails, no event
+		g.UpdateValue("b", 4)
+		g.NewEdge("a", "b")
+		g.NewEdge("a", "b") // exists, no event
+		g.NewEdge("b", "b")
+		g.NewEdge("b", "a")
+		g.DeleteEdge("b", "a")
+		g.DeleteNode("b")
+		expected := []Event{
+			{Type: NodeAdded, Key: "a", Value: 1},
+			{Type: NodeAdded, Key: "b", Value: 2},
+			{Type: NodeValueUpdated, Key: "b", Value: 4},
+			{Type: EdgeAdded, From: "a", To: "b"},
+			{Type: EdgeAdded, From: "b", To: "b"},
+			{Type: EdgeAdded, From: "b", To: "a"},
+			{Type: EdgeRemoved, From: "b", To: "a"},
+			{Type: EdgeRemoved, From: "b", To: "b"},
+			{Type: EdgeRemoved, From: "a", To: "b"},
+			{Type: NodeRemoved, Key: "b"},
+		}
+		got := receive(s)
+		if len(got) != len(expected) {
+			t.Fatalf("expected `%v` events got `%v`", len(expected), len(got))
+		}
+		for i := range expected {
+			expected[i].Sequence = uint64(i + 1)
+			if got[i] != expected[i] {
+				t.Errorf("expected `%v` got `%v`", expected[i], got[i])
+			}
+		}
+	})
+	t.Run("drop newest", func(t *testing.T) {
+		g := New()
+		s := g.Subscribe(SubscribeOptions{Buffer: 2, Policy: DropNewest})
+		for i := 0; i < 5; i++ {
+			g.NewNode(strconv.Itoa(i), nil)
+		}
+		got := receive(s)
+		if len(got) != 2 || got[0].Key != "0" || got[1].Key != "1" {
+			t.Errorf("expected events for nodes `0` and `1` got `%v`", got)
+		}
+		if s.Dropped() != 3 {
+			t.Errorf("expected `%v` dropped events got `%v`", 3, s.Dropped())
+		}
+	})
+	t.Run("drop oldest", func(t *testing.T) {
+		g := New()
+		s := g.Subscribe(SubscribeOptions{Buffer: 2, Policy: DropOldest})
+		for i := 0; i < 5; i++ {
+			g.NewNode(strconv.Itoa(i), nil)
+		}
+		got := receive(s)
+		if len(got) != 2 || got[0].Key != "3" || got[1].Key != "4" {
+			t.Errorf("expected events for nodes `3` and `4` got `%v`", got)
+		}
+		if s.Dropped() != 3 {
+			t.Errorf("expected `%v` dropped events got `%v`", 3, s.Dropped())
+		}
+	})
+	t.Run("close", func(t *testing.T) {
+		g := New()
+		a := g.Subscribe(SubscribeOptions{})
+		b := g.Subscribe(SubscribeOptions{})
+		g.NewNode("a", nil)
+		a.Close()
+		a.Close()
+		g.NewNode("b", nil)
+		if got := len(receive(b)); got != 2 {
+			t.Errorf("expected `%v` events got `%v`", 2, got)
+		}
+		var events []Event
+		for e := range a.Events() {
+			events = append(events, e)
+		}
+		if len(events) != 1 || events[0].Key != "a" {
+			t.Errorf("expected event for node `a` got `%v`", events)
+		}
+		if len(g.subscribers) != 1 {
+			t.Errorf("expected `%v` subscribers got `%v`", 1, len(g.subscribers))
+		}
+	})
+	t.Run("replacing graph", func(t *testing.T) {
+		g := New()
+		g.NewNode("a", nil)
+		g.NewNode("b", nil)
+		g.NewEdge("a", "b")
+		s := g.Subscribe(SubscribeOptions{})
+		g.UnmarshalJSON([]byte(`{"directed":true,"nodes":[{"id":"c"}],` +
+			`"links":[{"source":"c","target":"c"}]}`))
+		expected := []Event{
+			{Type: EdgeRemoved, From: "a", To: "b", Sequence: 4},
+			{Type: NodeRemoved, Key: "a", Sequence: 5},
+			{Type: NodeRemoved, Key: "b", Sequence: 6},
+			{Type: NodeAdded, Key: "c", Sequence: 7},
+			{Type: EdgeAdded, From: "c", To: "c", Sequence: 8},
+		}
+		got := receive(s)
+		if len(got) != len(expected) {
+			t.Fatalf("expected `%v` events got `%v`", len(expected), len(got))
+		}
+		for i := range expected {
+			if got[i] != expected[i] {
+				t.Errorf("expected `%v` got `%v`", expected[i], got[i])
+			}
+		}
+	})
+	t.Run("empty key", func(t *testing.T) {
+		g := emptyKey()
+		s := g.Subscribe(SubscribeOptions{})
+		g.DeleteNode("a")
+		g.UnmarshalJSON([]byte(`{"directed":true,"nodes":[{"id":""},{"id":"c"}],` +
+			`"links":[{"source":"c","target":""}]}`))
+		expected := []Event{
+			{Type: EdgeRemoved, From: "a", To: ""},
+			{Type: NodeRemoved, Key: "a"},
+			{Type: EdgeRemoved, From: "", To: "b"},
+			{Type: NodeRemoved, Key: ""},
+			{Type: NodeRemoved, Key: "b"},
+			{Type: NodeAdded, Key: ""},
+			{Type: NodeAdded, Key: "c"},
+			{Type: EdgeAdded, From: "c", To: ""},
+		}
+		got := receive(s)
+		if len(got) != len(expected) {
+			t.Fatalf("expected `%v` events got `%v`", len(expected), len(got))
+		}
+		for i := range expected {
+			got[i].Sequence = 0
+			if got[i] != expected[i] {
+				t.Errorf("expected `%v` got `%v`", expected[i], got[i])
+			}
+		}
+	})
+	t.Run("concurrent subscriber", func(t *testing.T) {
+		g := New()
+		s := g.Subscribe(SubscribeOptions{Buffer: 8, Policy: DropOldest})
+		var wg sync.WaitGroup
+		wg.Add(1)
+		var last uint64
+		ordered := true
+		go func() {
+			defer wg.Done()
+			for e := range s.Events() {
+				if e.Sequence <= last {
+					ordered = false
+				}
+				last = e.Sequence
+			}
+		}()
+		for i := 0; i < 1000; i++ {
+			g.NewNode(strconv.Itoa(i), nil)
+		}
+		s.Close()
+		wg.Wait()
+		if !ordered {
+			t.Errorf("events received out of order")
+		}
+		if last != 1000 {
+			t.Errorf("expected last sequence `%v` got `%v`", 1000, last)
+		}
+	})
+}
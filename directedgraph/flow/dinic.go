@@ -0,0 +1,56 @@
+package flow
+
+import (
+	"math"
+
+	"github.com/danrl/golibby/directedgraph"
+)
+
+// Dinic computes a maximum flow from source to sink using Dinic's algorithm.
+// It builds a level graph of the residual network and saturates it with a
+// blocking flow in each phase, which results in O(V^2*E) time.
+func Dinic(g *directedgraph.DirectedGraph, source, sink string) (*Result, error) {
+	n, err := newNetwork(g, source, sink)
+	if err != nil {
+		return nil, err
+	}
+	s, t := n.index[source], n.index[sink]
+	value := 0.0
+	for {
+		level := n.levels(s, nil)
+		if level[t] < 0 {
+			break
+		}
+		next := make([]int, len(n.keys))
+		for {
+			pushed := n.augment(s, t, math.Inf(1), level, next)
+			if pushed <= epsilon {
+				break
+			}
+			value += pushed
+		}
+	}
+	return n.result(s, value), nil
+}
+
+// augment recursively finds a path from u to t in the level graph and pushes
+// as much flow along it as possible. `next` holds the next arc to try for each
+// node, so that dead ends are never visited twice within a phase.
+func (n *network) augment(u, t int, limit float64, level, next []int) float64 {
+	if u == t {
+		return limit
+	}
+	for ; next[u] < len(n.head[u]); next[u]++ {
+		a := n.head[u][next[u]]
+		v := n.to[a]
+		if level[v] != level[u]+1 || n.residual[a] <= epsilon {
+			continue
+		}
+		if pushed := n.augment(v, t, math.Min(limit, n.residual[a]), level,
+			next); pushed > epsilon {
+			n.push(a, pushed)
+			return pushed
+		}
+	}
+	return 0
+}
@@ -0,0 +1,32 @@
+package flow
+
+import (
+	"math/rand"
+	"strconv"
+	"testing"
+
+	"github.com/danrl/golibby/directedgraph"
+)
+
+func TestDinic(t *testing.T) {
+	testAlgorithm(t, Dinic)
+}
+
+func TestDinicMatchesEdmondsKarp(t *testing.T) {
+	rng := rand.New(rand.NewSource(13))
+	for i := 0; i < 20; i++ {
+		g := directedgraph.New()
+		for j := 0; j < 30; j++ {
+			g.NewNode(strconv.Itoa(j), nil)
+		}
+		for j := 0; j < 150; j++ {
+			g.NewEdgeWithCapacity(strconv.Itoa(rng.Intn(30)),
+				strconv.Itoa(rng.Intn(30)), float64(rng.Intn(50)))
+		}
+		a, _ := EdmondsKarp(g, "0", "29")
+		b, _ := Dinic(g, "0", "29")
+		if a.Value != b.Value {
+			t.Errorf("expected value `%v` got `%v`", a.Value, b.Value)
+		}
+	}
+}
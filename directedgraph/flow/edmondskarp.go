@@ -0,0 +1,32 @@
+package flow
+
+import (
+	"github.com/danrl/golibby/directedgraph"
+)
+
+// EdmondsKarp computes a maximum flow from source to sink using the algorithm
+// by Edmonds and Karp. It repeatedly augments the flow along a shortest path
+// in the residual network and runs in O(V*E^2) time.
+func EdmondsKarp(g *directedgraph.DirectedGraph, source, sink string) (*Result, error) {
+	n, err := newNetwork(g, source, sink)
+	if err != nil {
+		return nil, err
+	}
+	s, t := n.index[source], n.index[sink]
+	value := 0.0
+	parent := make([]int, len(n.keys))
+	for n.levels(s, parent)[t] >= 0 {
+		// find bottleneck along the path, then augment
+		bottleneck := n.residual[parent[t]]
+		for v := t; v != s; v = n.to[parent[v]^1] {
+			if n.residual[parent[v]] < bottleneck {
+				bottleneck = n.residual[parent[v]]
+			}
+		}
+		for v := t; v != s; v = n.to[parent[v]^1] {
+			n.push(parent[v], bottleneck)
+		}
+		value += bottleneck
+	}
+	return n.result(s, value), nil
+}
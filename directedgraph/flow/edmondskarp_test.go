@@ -0,0 +1,9 @@
+package flow
+
+import (
+	"testing"
+)
+
+func TestEdmondsKarp(t *testing.T) {
+	testAlgorithm(t, EdmondsKarp)
+}
@@ -0,0 +1,164 @@
+// Package flow implements maximum flow algorithms on directed graphs. Edge
+// capacities are taken from a snapshot of the graph, see
+// directedgraph.DirectedGraph's Capacities method. The graph only accepts
+// finite capacities.
+package flow
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/danrl/golibby/directedgraph"
+)
+
+// ErrorSourceIsSink is returned when source and sink are the same node
+var ErrorSourceIsSink = fmt.Errorf("source is sink")
+
+// epsilon is the residual capacity below which an arc is considered saturated
+const epsilon = 1e-9
+
+// Edge is an edge of the graph
+type Edge struct {
+	From, To string
+}
+
+// Result holds a maximum flow from source to sink
+type Result struct {
+	// Value is the total amount of flow from source to sink
+	Value float64
+	// Flow holds the amount of flow along every edge of the graph
+	Flow map[string]map[string]float64
+	// MinCut holds the edges of a minimum cut, in lexical order. The sum of
+	// their capacities equals the value of the flow.
+	MinCut []Edge
+}
+
+// network is a residual network using dense node indices. Arcs are stored in
+// pairs, so that the reverse arc of arc a is a^1.
+type network struct {
+	keys     []string
+	index    map[string]int
+	head     [][]int
+	to       []int
+	residual []float64
+	capacity []float64
+}
+
+// newNetwork builds the residual network of a graph. Self-loops are ignored as
+// they can never carry flow from source to sink.
+func newNetwork(g *directedgraph.DirectedGraph, source, sink string) (*network, error) {
+	if source == sink {
+		return nil, ErrorSourceIsSink
+	}
+	// a single snapshot keeps the network consistent with concurrent changes
+	capacities := g.Capacities()
+	keys := make([]string, 0, len(capacities))
+	for key := range capacities {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	n := &network{
+		keys:  keys,
+		index: make(map[string]int, len(keys)),
+		head:  make([][]int, len(keys)),
+	}
+	for i, key := range keys {
+		n.index[key] = i
+	}
+	if _, ok := n.index[source]; !ok {
+		return nil, directedgraph.ErrorNodeNotFound
+	}
+	if _, ok := n.index[sink]; !ok {
+		return nil, directedgraph.ErrorNodeNotFound
+	}
+	for u, from := range keys {
+		edges := make([]string, 0, len(capacities[from]))
+		for to := range capacities[from] {
+			edges = append(edges, to)
+		}
+		sort.Strings(edges)
+		for _, to := range edges {
+			if to == from {
+				continue
+			}
+			n.addArc(u, n.index[to], capacities[from][to])
+		}
+	}
+	return n, nil
+}
+
+// addArc adds an arc and its reverse arc to the network
+func (n *network) addArc(u, v int, capacity float64) {
+	n.head[u] = append(n.head[u], len(n.to))
+	n.to = append(n.to, v)
+	n.residual = append(n.residual, capacity)
+	n.capacity = append(n.capacity, capacity)
+	n.head[v] = append(n.head[v], len(n.to))
+	n.to = append(n.to, u)
+	n.residual = append(n.residual, 0)
+	n.capacity = append(n.capacity, 0)
+}
+
+// push sends flow along an arc
+func (n *network) push(a int, flow float64) {
+	n.residual[a] -= flow
+	n.residual[a^1] += flow
+}
+
+// levels returns the BFS distance of every node from the source in the
+// residual network, or -1 for unreachable nodes. If parent is not nil, it
+// receives the arc used to discover every node.
+func (n *network) levels(s int, parent []int) []int {
+	level := make([]int, len(n.keys))
+	for i := range level {
+		level[i] = -1
+	}
+	level[s] = 0
+	queue := []int{s}
+	for len(queue) > 0 {
+		u := queue[0]
+		queue = queue[1:]
+		for _, a := range n.head[u] {
+			v := n.to[a]
+			if level[v] >= 0 || n.residual[a] <= epsilon {
+				continue
+			}
+			level[v] = level[u] + 1
+			if parent != nil {
+				parent[v] = a
+			}
+			queue = append(queue, v)
+		}
+	}
+	return level
+}
+
+// result extracts flow per edge and a minimum cut from a network carrying a
+// maximum flow
+func (n *network) result(s int, value float64) *Result {
+	r := &Result{
+		Value: value,
+		Flow:  make(map[string]map[string]float64),
+	}
+	level := n.levels(s, nil)
+	for u, from := range n.keys {
+		for _, a := range n.head[u] {
+			if a%2 == 1 {
+				continue
+			}
+			to := n.keys[n.to[a]]
+			flow := n.capacity[a] - n.residual[a]
+			if flow < epsilon {
+				flow = 0
+			}
+			if r.Flow[from] == nil {
+				r.Flow[from] = make(map[string]float64)
+			}
+			r.Flow[from][to] = flow
+			if level[u] >= 0 && level[n.to[a]] < 0 {
+				r.MinCut = append(r.MinCut, Edge{From: from, To: to})
+			}
+		}
+	}
+	return r
+}
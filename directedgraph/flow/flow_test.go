@@ -0,0 +1,228 @@
+package flow
+
+import (
+	"math"
+	"math/rand"
+	"strconv"
+	"testing"
+
+	"github.com/danrl/golibby/directedgraph"
+)
+
+type arc struct {
+	from, to string
+	capacity float64
+}
+
+// reference networks with known maximum flow values
+var networks = []struct {
+	name   string
+	arcs   []arc
+	source string
+	sink   string
+	value  float64
+	cut    []Edge
+}{
+	{
+		// Cormen et al., Introduction to Algorithms, figure 26.1
+		name: "clrs",
+		arcs: []arc{
+			{"s", "v1", 16}, {"s", "v2", 13}, {"v1", "v3", 12},
+			{"v2", "v1", 4}, {"v2", "v4", 14}, {"v3", "v2", 9},
+			{"v3", "t", 20}, {"v4", "v3", 7}, {"v4", "t", 4},
+		},
+		source: "s",
+		sink:   "t",
+		value:  23,
+		cut:    []Edge{{"v1", "v3"}, {"v4", "t"}, {"v4", "v3"}},
+	},
+	{
+		// Wikipedia, Dinic's algorithm
+		name: "wikipedia",
+		arcs: []arc{
+			{"s", "1", 10}, {"s", "2", 10}, {"1", "2", 2}, {"1", "3", 4},
+			{"1", "4", 8}, {"2", "4", 9}, {"4", "3", 6}, {"3", "t", 10},
+			{"4", "t", 10},
+		},
+		source: "s",
+		sink:   "t",
+		value:  19,
+		cut:    []Edge{{"2", "4"}, {"s", "1"}},
+	},
+	{
+		name: "antiparallel edges and self-loop",
+		arcs: []arc{
+			{"s", "a", 3}, {"a", "s", 5}, {"a", "a", 9}, {"a", "b", 2},
+			{"b", "a", 2}, {"a", "t", 2}, {"b", "t", 4},
+		},
+		source: "s",
+		sink:   "t",
+		value:  3,
+		cut:    []Edge{{"s", "a"}},
+	},
+	{
+		name: "unreachable sink",
+		arcs: []arc{
+			{"s", "a", 3}, {"t", "a", 3},
+		},
+		source: "s",
+		sink:   "t",
+		value:  0,
+		cut:    nil,
+	},
+}
+
+func build(arcs []arc) *directedgraph.DirectedGraph {
+	g := directedgraph.New()
+	for _, a := range arcs {
+		g.NewNode(a.from, nil)
+		g.NewNode(a.to, nil)
+		g.NewEdgeWithCapacity(a.from, a.to, a.capacity)
+	}
+	return g
+}
+
+// verify tests a flow for capacity constraints, flow conservation and that
+// the minimum cut has the same capacity as the flow
+func verify(t *testing.T, g *directedgraph.DirectedGraph, source, sink string, r *Result) {
+	balance := make(map[string]float64)
+	for _, from := range g.Nodes() {
+		to, _ := g.Edges(from)
+		for i := range to {
+			capacity, _ := g.Capacity(from, to[i])
+			flow := r.Flow[from][to[i]]
+			if flow < 0 || flow > capacity+epsilon {
+				t.Errorf("flow `%v` on edge `%v`->`%v` exceeds capacity `%v`",
+					flow, from, to[i], capacity)
+			}
+			balance[from] -= flow
+			balance[to[i]] += flow
+		}
+	}
+	for key, b := range balance {
+		switch key {
+		case source:
+			b = -b
+			fallthrough
+		case sink:
+			if math.Abs(b-r.Value) > 1e-6 {
+				t.Errorf("node `%v`: expected balance `%v` got `%v`", key, r.Value, b)
+			}
+		default:
+			if math.Abs(b) > 1e-6 {
+				t.Errorf("node `%v`: flow not conserved, balance `%v`", key, b)
+			}
+		}
+	}
+	cut := 0.0
+	for _, e := range r.MinCut {
+		capacity, _ := g.Capacity(e.From, e.To)
+		cut += capacity
+	}
+	if math.Abs(cut-r.Value) > 1e-6 {
+		t.Errorf("expected cut capacity `%v` got `%v`", r.Value, cut)
+	}
+}
+
+func equalCut(a, b []Edge) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// testAlgorithm runs an algorithm against the reference networks and a number
+// of random networks
+func testAlgorithm(t *testing.T, algorithm func(*directedgraph.DirectedGraph,
+	string, string) (*Result, error)) {
+	for _, tc := range networks {
+		t.Run(tc.name, func(t *testing.T) {
+			g := build(tc.arcs)
+			r, err := algorithm(g, tc.source, tc.sink)
+			if err != nil {
+				t.Fatalf("expected `%v` got `%v`", nil, err)
+			}
+			if r.Value != tc.value {
+				t.Errorf("expected value `%v` got `%v`", tc.value, r.Value)
+			}
+			if !equalCut(tc.cut, r.MinCut) {
+				t.Errorf("expected cut `%v` got `%v`", tc.cut, r.MinCut)
+			}
+			verify(t, g, tc.source, tc.sink, r)
+		})
+	}
+	t.Run("default capacities", func(t *testing.T) {
+		// two edge-disjoint paths
+		g := directedgraph.New()
+		for _, key := range []string{"s", "a", "b", "t"} {
+			g.NewNode(key, nil)
+		}
+		g.NewEdge("s", "a")
+		g.NewEdge("s", "b")
+		g.NewEdge("a", "b")
+		g.NewEdge("a", "t")
+		g.NewEdge("b", "t")
+		r, err := algorithm(g, "s", "t")
+		if err != nil {
+			t.Fatalf("expected `%v` got `%v`", nil, err)
+		}
+		if r.Value != 2 {
+			t.Errorf("expected value `%v` got `%v`", 2, r.Value)
+		}
+	})
+	t.Run("random networks", func(t *testing.T) {
+		rng := rand.New(rand.NewSource(7))
+		for i := 0; i < 20; i++ {
+			var arcs []arc
+			for j := 0; j < 60; j++ {
+				arcs = append(arcs, arc{
+					from:     strconv.Itoa(rng.Intn(15)),
+					to:       strconv.Itoa(rng.Intn(15)),
+					capacity: float64(rng.Intn(20)),
+				})
+			}
+			g := build(arcs)
+			g.NewNode("0", nil)
+			g.NewNode("14", nil)
+			r, err := algorithm(g, "0", "14")
+			if err != nil {
+				t.Fatalf("expected `%v` got `%v`", nil, err)
+			}
+			verify(t, g, "0", "14", r)
+		}
+	})
+	t.Run("invalid nodes", func(t *testing.T) {
+		g := build(networks[0].arcs)
+		if _, err := algorithm(g, "s", "s"); err != ErrorSourceIsSink {
+			t.Errorf("expected `%v` got `%v`", ErrorSourceIsSink, err)
+		}
+		if _, err := algorithm(g, "s", "x"); err != directedgraph.ErrorNodeNotFound {
+			t.Errorf("expected `%v` got `%v`", directedgraph.ErrorNodeNotFound, err)
+		}
+		if _, err := algorithm(g, "x", "t"); err != directedgraph.ErrorNodeNotFound {
+			t.Errorf("expected `%v` got `%v`", directedgraph.ErrorNodeNotFound, err)
+		}
+	})
+}
+
+func TestNewNetwork(t *testing.T) {
+	g := build(networks[2].arcs)
+	n, err := newNetwork(g, "s", "t")
+	if err != nil {
+		t.Fatalf("expected `%v` got `%v`", nil, err)
+	}
+	// self-loop is dropped, every other edge results in two arcs
+	if len(n.to) != 2*6 {
+		t.Errorf("expected `%v` arcs got `%v`", 2*6, len(n.to))
+	}
+	for a := range n.to {
+		if n.to[a^1] == n.to[a] {
+			t.Errorf("arc `%v` and its reverse point to the same node", a)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package directedgraph
+
+import (
+	"testing"
+
+	"github.com/danrl/golibby/graphalg"
+)
+
+func TestGraphFreeze(t *testing.T) {
+	g := diamond()
+	g.UpdateValue("a", 42)
+	g.NewEdge("e", "a")
+	c := g.Freeze()
+	if got := c.Nodes(); !equal(g.sortedNodes(), got) {
+		t.Errorf("expected `%v` got `%v`", g.sortedNodes(), got)
+	}
+	for _, key := range g.sortedNodes() {
+		expected, _ := g.Neighbors(key)
+		if got, _ := c.Neighbors(key); !equal(expected, got) {
+			t.Errorf("node `%v`: expected `%v` got `%v`", key, expected, got)
+		}
+		expected, _ = g.Predecessors(key)
+		if got, _ := c.Predecessors(key); !equal(expected, got) {
+			t.Errorf("node `%v`: expected `%v` got `%v`", key, expected, got)
+		}
+	}
+	if value, _ := c.Value("a"); value != 42 {
+		t.Errorf("expected `%v` got `%v`", 42, value)
+	}
+	g.DeleteEdge("a", "b")
+	if !c.HasEdge("a", "b") {
+		t.Errorf("expected edge `%v`->`%v` not found", "a", "b")
+	}
+	got, _ := graphalg.Ancestors(c, "a")
+	if expected := []string{"b", "c", "d", "e"}; !equal(expected, got) {
+		t.Errorf("expected `%v` got `%v`", expected, got)
+	}
+}
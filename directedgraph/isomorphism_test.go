@@ -0,0 +1,65 @@
+package directedgraph
+
+import (
+	"testing"
+
+	"github.com/danrl/golibby/graphalg"
+)
+
+func TestGraphIsomorphic(t *testing.T) {
+	other := New()
+	for _, key := range []string{"1", "2", "3", "4", "5", "6"} {
+		other.NewNode(key, nil)
+	}
+	other.NewEdge("1", "2")
+	other.NewEdge("1", "3")
+	other.NewEdge("1", "4")
+	other.NewEdge("2", "4")
+	other.NewEdge("3", "4")
+	other.NewEdge("4", "5")
+	got, err := diamond().Isomorphic(other, nil)
+	if err != nil {
+		t.Errorf("expected `%v` got `%v`", nil, err)
+	}
+	expected := map[string]string{"a": "1", "b": "2", "c": "3", "d": "4",
+		"e": "5", "f": "6"}
+	for from, to := range expected {
+		if got[from] != to {
+			t.Errorf("expected `%v` got `%v`", expected, got)
+		}
+	}
+	other.DeleteEdge("4", "5")
+	other.NewEdge("5", "4")
+	if _, err := diamond().Isomorphic(other, nil); err != graphalg.ErrorNotIsomorphic {
+		t.Errorf("expected `%v` got `%v`", graphalg.ErrorNotIsomorphic, err)
+	}
+}
+
+func TestGraphSubgraphMatches(t *testing.T) {
+	// a node with two successors that are not connected to each other
+	pattern := New()
+	for _, key := range []string{"x", "y", "z"} {
+		pattern.NewNode(key, nil)
+	}
+	pattern.NewEdge("x", "y")
+	pattern.NewEdge("x", "z")
+	var matches []map[string]string
+	diamond().SubgraphMatches(pattern, nil, func(m map[string]string) bool {
+		matches = append(matches, m)
+		return true
+	})
+	expected := []map[string]string{
+		{"x": "a", "y": "b", "z": "c"},
+		{"x": "a", "y": "c", "z": "b"},
+	}
+	if len(matches) != len(expected) {
+		t.Fatalf("expected `%v` got `%v`", expected, matches)
+	}
+	for i := range expected {
+		for from, to := range expected[i] {
+			if matches[i][from] != to {
+				t.Errorf("expected `%v` got `%v`", expected, matches)
+			}
+		}
+	}
+}
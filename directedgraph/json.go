@@ -0,0 +1,109 @@
+package directedgraph
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// nodeLink is the node-link JSON representation of a graph
+type nodeLink struct {
+	Directed bool           `json:"directed"`
+	Nodes    []nodeLinkNode `json:"nodes"`
+	Links    []nodeLinkLink `json:"links"`
+}
+
+type nodeLinkNode struct {
+	ID    string      `json:"id"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+type nodeLinkLink struct {
+	Source   string   `json:"source"`
+	Target   string   `json:"target"`
+	Capacity *float64 `json:"capacity,omitempty"`
+}
+
+// MarshalJSON encodes the graph in node-link format. Nodes and links are
+// written in lexical order. Links carry a capacity if one has been assigned
+// explicitly.
+func (g *DirectedGraph) MarshalJSON() ([]byte, error) {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+
+	nl := nodeLink{
+		Directed: true,
+		Nodes:    []nodeLinkNode{},
+		Links:    []nodeLinkLink{},
+	}
+	keys := g.sortedNodes()
+	for _, key := range keys {
+		nl.Nodes = append(nl.Nodes, nodeLinkNode{
+			ID:    key,
+			Value: g.nodes[key],
+		})
+	}
+	for _, from := range keys {
+		for _, to := range sortedKeys(g.edges[from]) {
+			link := nodeLinkLink{
+				Source: from,
+				Target: to,
+			}
+			if capacity, ok := g.capacities[from][to]; ok {
+				link.Capacity = &capacity
+			}
+			nl.Links = append(nl.Links, link)
+		}
+	}
+	return json.Marshal(nl)
+}
+
+// UnmarshalJSON decodes a graph in node-link format and replaces all nodes and
+// edges of the graph. Node values are decoded as by encoding/json into an empty
+// interface, e.g. numbers become float64. Graphs created by NewDAG return a
+// *CycleError if the decoded graph is cyclic. Subscribers receive removal
+// events for the replaced graph followed by events adding the decoded graph.
+func (g *DirectedGraph) UnmarshalJSON(data []byte) error {
+	var nl nodeLink
+	if err := json.Unmarshal(data, &nl); err != nil {
+		return fmt.Errorf("%w: %v", ErrorInvalidFormat, err)
+	}
+	if !nl.Directed {
+		return fmt.Errorf("%w: graph is undirected", ErrorInvalidFormat)
+	}
+	g.lock.RLock()
+	dag := g.order != nil
+	g.lock.RUnlock()
+	n := New()
+	if dag {
+		n = NewDAG()
+	}
+	for _, nd := range nl.Nodes {
+		if err := n.NewNode(nd.ID, nd.Value); err != nil {
+			return err
+		}
+	}
+	for _, l := range nl.Links {
+		var err error
+		if l.Capacity != nil {
+			err = n.NewEdgeWithCapacity(l.Source, l.Target, *l.Capacity)
+		} else {
+			err = n.NewEdge(l.Source, l.Target)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	g.emitRemoved()
+	g.nodes = n.nodes
+	g.edges = n.edges
+	g.reverse = n.reverse
+	g.capacities = n.capacities
+	g.order = n.order
+	g.nextOrder = n.nextOrder
+	g.emitAdded()
+	return nil
+}
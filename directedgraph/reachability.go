@@ -0,0 +1,188 @@
+package directedgraph
+
+import (
+	"sort"
+
+	"github.com/danrl/golibby/graphalg"
+)
+
+// view gives graph algorithms access to a graph whose lock is already held by
+// the caller
+type view struct {
+	g *DirectedGraph
+}
+
+func (v view) Nodes() []string {
+	return v.g.sortedNodes()
+}
+
+func (v view) Neighbors(key string) ([]string, error) {
+	if _, ok := v.g.nodes[key]; !ok {
+		return nil, ErrorNodeNotFound
+	}
+	return sortedKeys(v.g.edges[key]), nil
+}
+
+func (v view) Predecessors(key string) ([]string, error) {
+	if _, ok := v.g.nodes[key]; !ok {
+		return nil, ErrorNodeNotFound
+	}
+	return sortedKeys(v.g.reverse[key]), nil
+}
+
+func (v view) HasEdge(from, to string) bool {
+	return v.g.edges[from][to]
+}
+
+func (v view) Value(key string) (interface{}, error) {
+	value, ok := v.g.nodes[key]
+	if !ok {
+		return nil, ErrorNodeNotFound
+	}
+	return value, nil
+}
+
+// sortedKeys returns the keys of a set in lexical order, leaving out the
+// excluded keys
+func sortedKeys(set map[string]bool, exclude ...string) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		excluded := false
+		for _, x := range exclude {
+			if key == x {
+				excluded = true
+				break
+			}
+		}
+		if !excluded {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Descendants returns the keys of all nodes that can be reached from the node
+// identified by key, in lexical order. The node itself is never part of the
+// result, even if it is part of a cycle.
+func (g *DirectedGraph) Descendants(key string) ([]string, error) {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+
+	return graphalg.Descendants(view{g: g}, key)
+}
+
+// Ancestors returns the keys of all nodes from which the node identified by
+// key can be reached, in lexical order. The node itself is never part of the
+// result, even if it is part of a cycle.
+func (g *DirectedGraph) Ancestors(key string) ([]string, error) {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+
+	return graphalg.Ancestors(view{g: g}, key)
+}
+
+// Reachable returns true if there is a path leading from one node to another.
+// Every node is reachable from itself.
+func (g *DirectedGraph) Reachable(from, to string) (bool, error) {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+
+	return graphalg.Reachable(view{g: g}, from, to)
+}
+
+// ancestorSet returns the set of the node identified by key and all of its
+// ancestors
+func (g *DirectedGraph) ancestorSet(key string) map[string]bool {
+	ancestors, _ := graphalg.Ancestors(view{g: g}, key)
+	set := map[string]bool{key: true}
+	for _, from := range ancestors {
+		set[from] = true
+	}
+	return set
+}
+
+// descendantSets computes the set of descendants for every node of an acyclic
+// graph by walking the topological order backwards
+func (g *DirectedGraph) descendantSets() map[string]map[string]bool {
+	order := g.topOrder()
+	desc := make(map[string]map[string]bool, len(order))
+	for i := len(order) - 1; i >= 0; i-- {
+		from := order[i]
+		desc[from] = make(map[string]bool)
+		for to := range g.edges[from] {
+			desc[from][to] = true
+			for key := range desc[to] {
+				desc[from][key] = true
+			}
+		}
+	}
+	return desc
+}
+
+// copyNodes returns a new graph holding the nodes and values of the graph but
+// none of its edges
+func (g *DirectedGraph) copyNodes() *DirectedGraph {
+	c := New()
+	for key, value := range g.nodes {
+		c.nodes[key] = value
+		c.edges[key] = make(map[string]bool)
+		c.reverse[key] = make(map[string]bool)
+	}
+	return c
+}
+
+// TransitiveClosure returns a new graph that has an edge from every node to
+// every node reachable from it. It returns ErrorGraphIsCyclic if the graph is
+// not acyclic.
+func (g *DirectedGraph) TransitiveClosure() (*DirectedGraph, error) {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+
+	if g.isCyclic() {
+		return nil, ErrorGraphIsCyclic
+	}
+	c := g.copyNodes()
+	for from, desc := range g.descendantSets() {
+		for to := range desc {
+			c.edges[from][to] = true
+			c.reverse[to][from] = true
+		}
+	}
+	return c, nil
+}
+
+// TransitiveReduction returns a new graph with the fewest edges that still has
+// the same reachability relation as the graph. An edge is dropped if its
+// destination can also be reached through another successor of its source.
+// Remaining edges keep their capacities. It returns ErrorGraphIsCyclic if the
+// graph is not acyclic.
+func (g *DirectedGraph) TransitiveReduction() (*DirectedGraph, error) {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+
+	if g.isCyclic() {
+		return nil, ErrorGraphIsCyclic
+	}
+	desc := g.descendantSets()
+	r := g.copyNodes()
+	for from := range g.edges {
+		for to := range g.edges[from] {
+			redundant := false
+			for via := range g.edges[from] {
+				if via != to && desc[via][to] {
+					redundant = true
+					break
+				}
+			}
+			if !redundant {
+				r.edges[from][to] = true
+				r.reverse[to][from] = true
+				if capacity, ok := g.capacities[from][to]; ok {
+					r.setCapacity(from, to, capacity)
+				}
+			}
+		}
+	}
+	return r, nil
+}
@@ -0,0 +1,229 @@
+package directedgraph
+
+import (
+	"testing"
+)
+
+// diamond returns an acyclic graph with one redundant edge `a`->`d`
+func diamond() *DirectedGraph {
+	g := New()
+	for _, key := range []string{"a", "b", "c", "d", "e", "f"} {
+		g.NewNode(key, nil)
+	}
+	g.NewEdge("a", "b")
+	g.NewEdge("a", "c")
+	g.NewEdge("a", "d")
+	g.NewEdge("b", "d")
+	g.NewEdge("c", "d")
+	g.NewEdge("d", "e")
+	return g
+}
+
+// emptyKey returns a graph with a path from `a` to `b` through a node keyed by
+// the empty string
+func emptyKey() *DirectedGraph {
+	g := New()
+	for _, key := range []string{"a", "", "b"} {
+		g.NewNode(key, nil)
+	}
+	g.NewEdge("a", "")
+	g.NewEdge("", "b")
+	return g
+}
+
+func TestGraphEmptyKeyReachability(t *testing.T) {
+	g := emptyKey()
+	descendants, _ := g.Descendants("a")
+	if expected := []string{"", "b"}; !equal(expected, descendants) {
+		t.Errorf("expected `%v` got `%v`", expected, descendants)
+	}
+	ancestors, _ := g.Ancestors("b")
+	if expected := []string{"", "a"}; !equal(expected, ancestors) {
+		t.Errorf("expected `%v` got `%v`", expected, ancestors)
+	}
+	for _, pair := range [][2]string{{"a", ""}, {"", "b"}, {"a", "b"}} {
+		if ok, _ := g.Reachable(pair[0], pair[1]); !ok {
+			t.Errorf("`%v` to `%v`: expected `%v` got `%v`", pair[0], pair[1], true, ok)
+		}
+	}
+}
+
+func TestGraphDescendants(t *testing.T) {
+	t.Run("acyclic graph", func(t *testing.T) {
+		g := diamond()
+		tt := []struct {
+			key      string
+			expected []string
+		}{
+			{key: "a", expected: []string{"b", "c", "d", "e"}},
+			{key: "b", expected: []string{"d", "e"}},
+			{key: "e", expected: []string{}},
+			{key: "f", expected: []string{}},
+		}
+		for _, tc := range tt {
+			got, err := g.Descendants(tc.key)
+			if err != nil {
+				t.Errorf("node `%v`: %v", tc.key, err)
+			}
+			if !equal(tc.expected, got) {
+				t.Errorf("node `%v`: expected `%v` got `%v`", tc.key, tc.expected, got)
+			}
+		}
+	})
+	t.Run("cyclic graph", func(t *testing.T) {
+		g := diamond()
+		g.NewEdge("e", "a")
+		got, err := g.Descendants("a")
+		if err != nil {
+			t.Errorf("node `%v`: %v", "a", err)
+		}
+		expected := []string{"b", "c", "d", "e"}
+		if !equal(expected, got) {
+			t.Errorf("expected `%v` got `%v`", expected, got)
+		}
+	})
+	t.Run("unknown node", func(t *testing.T) {
+		g := New()
+		_, err := g.Descendants("foo")
+		if err != ErrorNodeNotFound {
+			t.Errorf("expected `%v` got `%v`", ErrorNodeNotFound, err)
+		}
+	})
+}
+
+func TestGraphAncestors(t *testing.T) {
+	t.Run("acyclic graph", func(t *testing.T) {
+		g := diamond()
+		tt := []struct {
+			key      string
+			expected []string
+		}{
+			{key: "a", expected: []string{}},
+			{key: "d", expected: []string{"a", "b", "c"}},
+			{key: "e", expected: []string{"a", "b", "c", "d"}},
+		}
+		for _, tc := range tt {
+			got, err := g.Ancestors(tc.key)
+			if err != nil {
+				t.Errorf("node `%v`: %v", tc.key, err)
+			}
+			if !equal(tc.expected, got) {
+				t.Errorf("node `%v`: expected `%v` got `%v`", tc.key, tc.expected, got)
+			}
+		}
+	})
+	t.Run("unknown node", func(t *testing.T) {
+		g := New()
+		_, err := g.Ancestors("foo")
+		if err != ErrorNodeNotFound {
+			t.Errorf("expected `%v` got `%v`", ErrorNodeNotFound, err)
+		}
+	})
+}
+
+func TestGraphReachable(t *testing.T) {
+	t.Run("acyclic graph", func(t *testing.T) {
+		g := diamond()
+		tt := []struct {
+			from, to string
+			expected bool
+		}{
+			{from: "a", to: "e", expected: true},
+			{from: "c", to: "d", expected: true},
+			{from: "e", to: "a", expected: false},
+			{from: "b", to: "c", expected: false},
+			{from: "f", to: "f", expected: true},
+		}
+		for _, tc := range tt {
+			got, err := g.Reachable(tc.from, tc.to)
+			if err != nil {
+				t.Errorf("`%v`->`%v`: %v", tc.from, tc.to, err)
+			}
+			if got != tc.expected {
+				t.Errorf("`%v`->`%v`: expected `%v` got `%v`", tc.from, tc.to, tc.expected, got)
+			}
+		}
+	})
+	t.Run("unknown nodes", func(t *testing.T) {
+		g := New()
+		g.NewNode("foo", nil)
+		_, err := g.Reachable("foo", "unknown")
+		if err != ErrorNodeNotFound {
+			t.Errorf("expected `%v` got `%v`", ErrorNodeNotFound, err)
+		}
+		_, err = g.Reachable("unknown", "foo")
+		if err != ErrorNodeNotFound {
+			t.Errorf("expected `%v` got `%v`", ErrorNodeNotFound, err)
+		}
+	})
+}
+
+func TestGraphTransitiveClosure(t *testing.T) {
+	t.Run("acyclic graph", func(t *testing.T) {
+		g := diamond()
+		c, err := g.TransitiveClosure()
+		if err != nil {
+			t.Fatalf("expected `%v` got `%v`", nil, err)
+		}
+		for _, key := range g.Nodes() {
+			desc, _ := g.Descendants(key)
+			got, _ := c.Edges(key)
+			if len(got) != len(desc) {
+				t.Errorf("node `%v`: expected `%v` edges got `%v`", key, len(desc), len(got))
+			}
+			for _, to := range desc {
+				if !c.edges[key][to] || !c.reverse[to][key] {
+					t.Errorf("expected edge `%v`->`%v` not found.", key, to)
+				}
+			}
+		}
+	})
+	t.Run("cyclic graph", func(t *testing.T) {
+		g := diamond()
+		g.NewEdge("e", "a")
+		_, err := g.TransitiveClosure()
+		if err != ErrorGraphIsCyclic {
+			t.Errorf("expected `%v` got `%v`", ErrorGraphIsCyclic, err)
+		}
+	})
+}
+
+func TestGraphTransitiveReduction(t *testing.T) {
+	t.Run("acyclic graph", func(t *testing.T) {
+		g := diamond()
+		r, err := g.TransitiveReduction()
+		if err != nil {
+			t.Fatalf("expected `%v` got `%v`", nil, err)
+		}
+		if r.edges["a"]["d"] || r.reverse["d"]["a"] {
+			t.Errorf("unexpected edge `%v`->`%v` found.", "a", "d")
+		}
+		for _, e := range [][2]string{{"a", "b"}, {"a", "c"}, {"b", "d"},
+			{"c", "d"}, {"d", "e"}} {
+			if !r.edges[e[0]][e[1]] || !r.reverse[e[1]][e[0]] {
+				t.Errorf("expected edge `%v`->`%v` not found.", e[0], e[1])
+			}
+		}
+		if len(r.Nodes()) != len(g.Nodes()) {
+			t.Errorf("expected `%v` nodes, got `%v`", len(g.Nodes()), len(r.Nodes()))
+		}
+		// reduction of the closure is the reduction of the graph
+		c, _ := g.TransitiveClosure()
+		rc, _ := c.TransitiveReduction()
+		for _, key := range g.Nodes() {
+			a, _ := r.Edges(key)
+			b, _ := rc.Edges(key)
+			if len(a) != len(b) {
+				t.Errorf("node `%v`: expected `%v` edges got `%v`", key, len(a), len(b))
+			}
+		}
+	})
+	t.Run("cyclic graph", func(t *testing.T) {
+		g := diamond()
+		g.NewEdge("d", "d")
+		_, err := g.TransitiveReduction()
+		if err != ErrorGraphIsCyclic {
+			t.Errorf("expected `%v` got `%v`", ErrorGraphIsCyclic, err)
+		}
+	})
+}
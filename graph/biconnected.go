@@ -0,0 +1,165 @@
+package graph
+
+import (
+	"sort"
+)
+
+// frame is an entry on the explicit stack of the iterative depth first search
+// in biconnected
+type frame struct {
+	key       string
+	parent    string
+	neighbors []string
+	next      int
+}
+
+// biconnected runs Tarjan's low-link algorithm on every connected component
+// without acquiring the lock. The depth first search keeps an explicit stack,
+// so that the call stack does not grow with the size of the graph. It returns
+// the articulation points, the bridges and the node sets of the biconnected
+// components in no particular order. Self-loops are ignored.
+func (g *Graph) biconnected() ([]string, []Edge, [][]string) {
+	var points []string
+	var bridges []Edge
+	var components [][]string
+
+	disc := make(map[string]int, len(g.nodes))
+	low := make(map[string]int, len(g.nodes))
+	var edges [][2]string
+	counter := 0
+	for _, root := range g.sortedNodes() {
+		if _, ok := disc[root]; ok {
+			continue
+		}
+		disc[root] = counter
+		low[root] = counter
+		counter++
+		stack := []*frame{{key: root, neighbors: g.adjacent(root)}}
+		rootChildren := 0
+		for len(stack) > 0 {
+			f := stack[len(stack)-1]
+			if f.next < len(f.neighbors) {
+				to := f.neighbors[f.next]
+				f.next++
+				if _, ok := disc[to]; !ok {
+					// tree edge
+					disc[to] = counter
+					low[to] = counter
+					counter++
+					edges = append(edges, [2]string{f.key, to})
+					stack = append(stack, &frame{
+						key:       to,
+						parent:    f.key,
+						neighbors: g.adjacent(to),
+					})
+					if f.key == root {
+						rootChildren++
+					}
+				} else if to != f.parent && disc[to] < disc[f.key] {
+					// back edge to an ancestor
+					edges = append(edges, [2]string{f.key, to})
+					if disc[to] < low[f.key] {
+						low[f.key] = disc[to]
+					}
+				}
+				continue
+			}
+
+			// all neighbors done, return to the parent
+			stack = stack[:len(stack)-1]
+			if len(stack) == 0 {
+				break
+			}
+			parent := stack[len(stack)-1].key
+			if low[f.key] < low[parent] {
+				low[parent] = low[f.key]
+			}
+			if low[f.key] > disc[parent] {
+				bridges = append(bridges, newEdge(parent, f.key,
+					g.weight(parent, f.key)))
+			}
+			if low[f.key] >= disc[parent] {
+				if parent != root {
+					points = append(points, parent)
+				}
+				// the edges above the tree edge form a biconnected component
+				set := make(map[string]bool)
+				for {
+					e := edges[len(edges)-1]
+					edges = edges[:len(edges)-1]
+					set[e[0]] = true
+					set[e[1]] = true
+					if e[0] == parent && e[1] == f.key {
+						break
+					}
+				}
+				component := make([]string, 0, len(set))
+				for key := range set {
+					component = append(component, key)
+				}
+				components = append(components, component)
+			}
+		}
+		if rootChildren > 1 {
+			points = append(points, root)
+		}
+	}
+	return points, bridges, components
+}
+
+// ArticulationPoints returns the keys of all nodes whose removal would increase
+// the number of connected components, in lexical order
+func (g *Graph) ArticulationPoints() []string {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+
+	points, _, _ := g.biconnected()
+	// a node is reported once for every component it separates
+	unique := make([]string, 0, len(points))
+	seen := make(map[string]bool)
+	for _, key := range points {
+		if !seen[key] {
+			seen[key] = true
+			unique = append(unique, key)
+		}
+	}
+	sort.Strings(unique)
+	return unique
+}
+
+// Bridges returns all edges whose removal would increase the number of
+// connected components. Bridges are ordered by their keys.
+func (g *Graph) Bridges() []Edge {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+
+	_, bridges, _ := g.biconnected()
+	sortEdges(bridges)
+	return bridges
+}
+
+// BiconnectedComponents returns the node sets of the biconnected components of
+// the graph, i.e. the maximal subgraphs that stay connected if any single node
+// is removed. A bridge forms a component of its own. Articulation points are
+// part of several components, nodes without edges are part of none. Every
+// component lists its keys in lexical order, components are ordered
+// lexically.
+func (g *Graph) BiconnectedComponents() [][]string {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+
+	_, _, components := g.biconnected()
+	for _, component := range components {
+		sort.Strings(component)
+	}
+	sort.Slice(components, func(i, j int) bool {
+		a, b := components[i], components[j]
+		for k := 0; k < len(a) && k < len(b); k++ {
+			if a[k] != b[k] {
+				return a[k] < b[k]
+			}
+		}
+		return len(a) < len(b)
+	})
+	return components
+}
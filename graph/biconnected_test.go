@@ -0,0 +1,120 @@
+package graph
+
+import (
+	"strconv"
+	"testing"
+)
+
+// topology returns a graph of two triangles joined by a bridge, with a dangling
+// node, an isolated node and a separate pair of nodes
+//
+//	a       e
+//	| \   / |
+//	|  c-d  |    h    i-j
+//	| /   \ |
+//	b       f-g
+func topology() *Graph {
+	g := New()
+	for _, key := range []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j"} {
+		g.NewNode(key, nil)
+	}
+	for _, e := range [][2]string{{"a", "b"}, {"b", "c"}, {"c", "a"}, {"c", "d"},
+		{"d", "e"}, {"e", "f"}, {"f", "d"}, {"f", "g"}, {"i", "j"}} {
+		g.NewEdge(e[0], e[1])
+	}
+	g.NewEdge("a", "a")
+	return g
+}
+
+// path returns a graph with n nodes connected in a line
+func path(n int) *Graph {
+	g := New()
+	for i := 0; i < n; i++ {
+		g.NewNode(strconv.Itoa(i), nil)
+	}
+	for i := 1; i < n; i++ {
+		g.NewEdge(strconv.Itoa(i-1), strconv.Itoa(i))
+	}
+	return g
+}
+
+func TestGraphArticulationPoints(t *testing.T) {
+	t.Run("empty graph", func(t *testing.T) {
+		if got := New().ArticulationPoints(); len(got) != 0 {
+			t.Errorf("expected `%v` got `%v`", nil, got)
+		}
+	})
+	t.Run("topology", func(t *testing.T) {
+		expected := []string{"c", "d", "f"}
+		if got := topology().ArticulationPoints(); !equal(expected, got) {
+			t.Errorf("expected `%v` got `%v`", expected, got)
+		}
+	})
+	t.Run("root with several children", func(t *testing.T) {
+		g := New()
+		for _, key := range []string{"a", "b", "c", "d"} {
+			g.NewNode(key, nil)
+		}
+		g.NewEdge("a", "b")
+		g.NewEdge("a", "c")
+		g.NewEdge("a", "d")
+		g.NewEdge("c", "d")
+		expected := []string{"a"}
+		if got := g.ArticulationPoints(); !equal(expected, got) {
+			t.Errorf("expected `%v` got `%v`", expected, got)
+		}
+	})
+	t.Run("large graph", func(t *testing.T) {
+		n := 100000
+		if got := path(n).ArticulationPoints(); len(got) != n-2 {
+			t.Errorf("expected `%v` points got `%v`", n-2, len(got))
+		}
+	})
+}
+
+func TestGraphBridges(t *testing.T) {
+	t.Run("topology", func(t *testing.T) {
+		expected := []Edge{{"c", "d", 1}, {"f", "g", 1}, {"i", "j", 1}}
+		got := topology().Bridges()
+		if len(got) != len(expected) {
+			t.Fatalf("expected `%v` got `%v`", expected, got)
+		}
+		for i := range expected {
+			if expected[i] != got[i] {
+				t.Errorf("expected `%v` got `%v`", expected[i], got[i])
+			}
+		}
+	})
+	t.Run("cycle", func(t *testing.T) {
+		g := path(5)
+		g.NewEdge("4", "0")
+		if got := g.Bridges(); len(got) != 0 {
+			t.Errorf("expected `%v` got `%v`", nil, got)
+		}
+	})
+}
+
+func TestGraphBiconnectedComponents(t *testing.T) {
+	t.Run("topology", func(t *testing.T) {
+		expected := [][]string{{"a", "b", "c"}, {"c", "d"}, {"d", "e", "f"},
+			{"f", "g"}, {"i", "j"}}
+		got := topology().BiconnectedComponents()
+		if len(got) != len(expected) {
+			t.Fatalf("expected `%v` got `%v`", expected, got)
+		}
+		for i := range expected {
+			if !equal(expected[i], got[i]) {
+				t.Errorf("expected `%v` got `%v`", expected[i], got[i])
+			}
+		}
+	})
+	t.Run("large graph", func(t *testing.T) {
+		n := 100000
+		g := path(n)
+		g.NewEdge(strconv.Itoa(n-1), "0")
+		got := g.BiconnectedComponents()
+		if len(got) != 1 || len(got[0]) != n {
+			t.Errorf("expected a single component of `%v` nodes", n)
+		}
+	})
+}
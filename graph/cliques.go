@@ -0,0 +1,184 @@
+package graph
+
+import (
+	"sort"
+)
+
+// degeneracy computes the core number of every node and a degeneracy ordering
+// without acquiring the lock, using the bucket algorithm of Batagelj and
+// Zaversnik. In the ordering every node has at most as many later neighbors as
+// the degeneracy of the graph. Self-loops are ignored.
+func (g *Graph) degeneracy() (map[string]int, []string) {
+	keys := g.sortedNodes()
+	degree := make(map[string]int, len(keys))
+	maxDegree := 0
+	for _, key := range keys {
+		degree[key] = len(g.adjacent(key))
+		if degree[key] > maxDegree {
+			maxDegree = degree[key]
+		}
+	}
+	buckets := make([][]string, maxDegree+1)
+	for _, key := range keys {
+		buckets[degree[key]] = append(buckets[degree[key]], key)
+	}
+
+	core := make(map[string]int, len(keys))
+	order := make([]string, 0, len(keys))
+	removed := make(map[string]bool, len(keys))
+	k := 0
+	for d := 0; d <= maxDegree; {
+		if len(buckets[d]) == 0 {
+			d++
+			continue
+		}
+		key := buckets[d][0]
+		buckets[d] = buckets[d][1:]
+		// buckets hold stale entries of nodes whose degree has dropped since
+		if removed[key] || degree[key] != d {
+			continue
+		}
+		if d > k {
+			k = d
+		}
+		core[key] = k
+		order = append(order, key)
+		removed[key] = true
+		for _, to := range g.adjacent(key) {
+			if !removed[to] {
+				degree[to]--
+				buckets[degree[to]] = append(buckets[degree[to]], to)
+				if degree[to] < d {
+					d = degree[to]
+				}
+			}
+		}
+	}
+	return core, order
+}
+
+// KCores returns the core number of every node, i.e. the largest k for which
+// the node is part of the k-core, the maximal subgraph in which every node has
+// at least k neighbors. Self-loops are ignored.
+func (g *Graph) KCores() map[string]int {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+
+	core, _ := g.degeneracy()
+	return core
+}
+
+// intersect returns the keys of a sorted list that are neighbors of a node,
+// keeping their order
+func (g *Graph) intersect(keys []string, key string) []string {
+	var out []string
+	for _, other := range keys {
+		if other != key && g.edges[key][other] {
+			out = append(out, other)
+		}
+	}
+	return out
+}
+
+// bronKerbosch extends the clique r by candidates p, excluding cliques that
+// contain any node of x, using the pivot that has the most neighbors among the
+// candidates. Branches are skipped if prune returns true for the size of the
+// clique and the number of candidates. It returns false if yield asked to stop.
+func (g *Graph) bronKerbosch(r, p, x []string, prune func(size, candidates int) bool,
+	yield func([]string) bool) bool {
+	if len(p) == 0 {
+		if len(x) > 0 {
+			return true
+		}
+		clique := make([]string, len(r))
+		copy(clique, r)
+		sort.Strings(clique)
+		return yield(clique)
+	}
+	if prune != nil && prune(len(r), len(p)) {
+		return true
+	}
+
+	pivot := ""
+	best := -1
+	for _, keys := range [][]string{p, x} {
+		for _, u := range keys {
+			if n := len(g.intersect(p, u)); n > best {
+				pivot = u
+				best = n
+			}
+		}
+	}
+	for i := 0; i < len(p); {
+		v := p[i]
+		if g.edges[pivot][v] && v != pivot {
+			i++
+			continue
+		}
+		if !g.bronKerbosch(append(r, v), g.intersect(p, v), g.intersect(x, v),
+			prune, yield) {
+			return false
+		}
+		// move v from the candidates to the excluded nodes
+		p = append(p[:i:i], p[i+1:]...)
+		x = append(x[:len(x):len(x)], v)
+	}
+	return true
+}
+
+// cliques runs Bron–Kerbosch on every node in degeneracy order without
+// acquiring the lock. The later neighbors of a node are its candidates, the
+// earlier neighbors are excluded.
+func (g *Graph) cliques(prune func(size, candidates int) bool,
+	yield func([]string) bool) {
+	_, order := g.degeneracy()
+	position := make(map[string]int, len(order))
+	for i, key := range order {
+		position[key] = i
+	}
+	for i, key := range order {
+		var p, x []string
+		for _, to := range g.adjacent(key) {
+			if position[to] > i {
+				p = append(p, to)
+			} else {
+				x = append(x, to)
+			}
+		}
+		if !g.bronKerbosch([]string{key}, p, x, prune, yield) {
+			return
+		}
+	}
+}
+
+// MaximalCliques calls yield for every maximal clique of the graph, i.e. every
+// set of pairwise connected nodes that cannot be extended by another node.
+// Cliques are enumerated by the Bron–Kerbosch algorithm with pivoting, started
+// from every node in degeneracy order. Every clique lists its keys in lexical
+// order. The enumeration stops early if yield returns false. The yield function
+// must not modify the graph.
+func (g *Graph) MaximalCliques(yield func([]string) bool) {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+
+	g.cliques(nil, yield)
+}
+
+// MaximumClique returns the keys of a largest clique of the graph in lexical
+// order. Branches of the enumeration that cannot yield a larger clique than
+// the largest one found so far are skipped.
+func (g *Graph) MaximumClique() []string {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+
+	var best []string
+	g.cliques(func(size, candidates int) bool {
+		return size+candidates <= len(best)
+	}, func(clique []string) bool {
+		if len(clique) > len(best) {
+			best = clique
+		}
+		return true
+	})
+	return best
+}
@@ -0,0 +1,115 @@
+package graph
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// ring returns a graph with a complete subgraph of four nodes, a triangle
+// sharing a node with it, a dangling edge and an isolated node
+func ring() *Graph {
+	g := New()
+	for _, key := range []string{"a", "b", "c", "d", "e", "f", "g", "h"} {
+		g.NewNode(key, nil)
+	}
+	for _, e := range [][2]string{{"a", "b"}, {"a", "c"}, {"a", "d"}, {"b", "c"},
+		{"b", "d"}, {"c", "d"}, {"d", "e"}, {"d", "f"}, {"e", "f"}, {"f", "g"}} {
+		g.NewEdge(e[0], e[1])
+	}
+	g.NewEdge("h", "h")
+	return g
+}
+
+// moonMoser returns the complete multipartite graph with k parts of three
+// nodes, which has 3^k maximal cliques
+func moonMoser(k int) *Graph {
+	g := New()
+	for i := 0; i < 3*k; i++ {
+		g.NewNode(strconv.Itoa(i), nil)
+	}
+	for i := 0; i < 3*k; i++ {
+		for j := i + 1; j < 3*k; j++ {
+			if i/3 != j/3 {
+				g.NewEdge(strconv.Itoa(i), strconv.Itoa(j))
+			}
+		}
+	}
+	return g
+}
+
+func TestGraphMaximalCliques(t *testing.T) {
+	t.Run("ring", func(t *testing.T) {
+		found := make(map[string]bool)
+		ring().MaximalCliques(func(clique []string) bool {
+			found[strings.Join(clique, ",")] = true
+			return true
+		})
+		expected := [][]string{{"a", "b", "c", "d"}, {"d", "e", "f"}, {"f", "g"},
+			{"h"}}
+		if len(found) != len(expected) {
+			t.Errorf("expected `%v` cliques got `%v`", len(expected), found)
+		}
+		for _, clique := range expected {
+			if !found[strings.Join(clique, ",")] {
+				t.Errorf("expected clique `%v` not found", clique)
+			}
+		}
+	})
+	t.Run("exponentially many cliques", func(t *testing.T) {
+		count := 0
+		moonMoser(5).MaximalCliques(func(clique []string) bool {
+			if len(clique) != 5 {
+				t.Errorf("expected clique of size `%v` got `%v`", 5, clique)
+			}
+			count++
+			return true
+		})
+		if count != 243 {
+			t.Errorf("expected `%v` cliques got `%v`", 243, count)
+		}
+	})
+	t.Run("early stop", func(t *testing.T) {
+		count := 0
+		moonMoser(8).MaximalCliques(func(clique []string) bool {
+			count++
+			return count < 10
+		})
+		if count != 10 {
+			t.Errorf("expected `%v` cliques got `%v`", 10, count)
+		}
+	})
+}
+
+func TestGraphMaximumClique(t *testing.T) {
+	t.Run("empty graph", func(t *testing.T) {
+		if got := New().MaximumClique(); len(got) != 0 {
+			t.Errorf("expected `%v` got `%v`", nil, got)
+		}
+	})
+	t.Run("ring", func(t *testing.T) {
+		expected := []string{"a", "b", "c", "d"}
+		if got := ring().MaximumClique(); !equal(expected, got) {
+			t.Errorf("expected `%v` got `%v`", expected, got)
+		}
+	})
+	t.Run("multipartite graph", func(t *testing.T) {
+		if got := moonMoser(10).MaximumClique(); len(got) != 10 {
+			t.Errorf("expected clique of size `%v` got `%v`", 10, got)
+		}
+	})
+}
+
+func TestGraphKCores(t *testing.T) {
+	expected := map[string]int{"a": 3, "b": 3, "c": 3, "d": 3, "e": 2, "f": 2,
+		"g": 1, "h": 0}
+	got := ring().KCores()
+	if len(got) != len(expected) {
+		t.Errorf("expected `%v` got `%v`", expected, got)
+	}
+	for key, core := range expected {
+		if got[key] != core {
+			t.Errorf("node `%v`: expected `%v` got `%v`", key, core, got[key])
+		}
+	}
+}
@@ -0,0 +1,262 @@
+package graph
+
+import (
+	"fmt"
+	"sort"
+)
+
+var (
+	// ErrorInvalidOrder is returned when an order does not list every node of
+	// the graph exactly once
+	ErrorInvalidOrder = fmt.Errorf("invalid order")
+	// ErrorTooManyNodes is returned when a graph exceeds the node limit of an
+	// exact search
+	ErrorTooManyNodes = fmt.Errorf("too many nodes")
+)
+
+// IsBipartite reports whether the nodes of the graph can be split into two
+// partitions so that every edge connects nodes of different partitions. If so,
+// the partitions are returned in lexical order, with the lexically smallest key
+// of every connected component in the first partition. Otherwise an odd cycle
+// is returned as proof, listing the nodes along the cycle with the edge from
+// the last node back to the first node implied. A self-loop is an odd cycle of
+// length one.
+func (g *Graph) IsBipartite() (bool, [2][]string, []string) {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+
+	side := make(map[string]int)
+	parent := make(map[string]string)
+	depth := make(map[string]int)
+	for _, start := range g.sortedNodes() {
+		if _, ok := side[start]; ok {
+			continue
+		}
+		side[start] = 0
+		queue := []string{start}
+		for len(queue) > 0 {
+			from := queue[0]
+			queue = queue[1:]
+			for _, to := range g.sortedNeighbors(from) {
+				if _, ok := side[to]; !ok {
+					side[to] = 1 - side[from]
+					parent[to] = from
+					depth[to] = depth[from] + 1
+					queue = append(queue, to)
+					continue
+				}
+				if side[to] == side[from] {
+					return false, [2][]string{}, oddCycle(parent, depth, from, to)
+				}
+			}
+		}
+	}
+
+	var partitions [2][]string
+	for _, key := range g.sortedNodes() {
+		partitions[side[key]] = append(partitions[side[key]], key)
+	}
+	return true, partitions, nil
+}
+
+// oddCycle returns the cycle closed by an edge between two nodes of the same
+// depth parity in a breadth first search tree. The cycle runs from a up to the
+// lowest common ancestor of a and b and down again to b.
+func oddCycle(parent map[string]string, depth map[string]int, a, b string) []string {
+	var up, down []string
+	for depth[a] > depth[b] {
+		up = append(up, a)
+		a = parent[a]
+	}
+	for depth[b] > depth[a] {
+		down = append(down, b)
+		b = parent[b]
+	}
+	for a != b {
+		up = append(up, a)
+		down = append(down, b)
+		a, b = parent[a], parent[b]
+	}
+	cycle := append(up, a)
+	for i := len(down) - 1; i >= 0; i-- {
+		cycle = append(cycle, down[i])
+	}
+	return cycle
+}
+
+// adjacent returns the keys of all nodes connected to a node in lexical order,
+// leaving out the node itself
+func (g *Graph) adjacent(from string) []string {
+	keys := g.sortedNeighbors(from)
+	for i, key := range keys {
+		if key == from {
+			return append(keys[:i], keys[i+1:]...)
+		}
+	}
+	return keys
+}
+
+// smallestFreeColor returns the smallest color not used by any colored
+// neighbor of a node
+func (g *Graph) smallestFreeColor(key string, colors map[string]int) int {
+	used := make(map[int]bool)
+	for _, to := range g.adjacent(key) {
+		if color, ok := colors[to]; ok {
+			used[color] = true
+		}
+	}
+	color := 0
+	for used[color] {
+		color++
+	}
+	return color
+}
+
+// GreedyColoring colors the nodes of the graph one after another in the given
+// order, assigning every node the smallest color not used by its neighbors.
+// Colors are numbered from zero. ErrorInvalidOrder is returned if order does
+// not list every node of the graph exactly once. Self-loops are ignored.
+func (g *Graph) GreedyColoring(order []string) (map[string]int, error) {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+
+	if len(order) != len(g.nodes) {
+		return nil, ErrorInvalidOrder
+	}
+	colors := make(map[string]int, len(order))
+	for _, key := range order {
+		if _, ok := g.nodes[key]; !ok {
+			return nil, ErrorNodeNotFound
+		}
+		if _, ok := colors[key]; ok {
+			return nil, ErrorInvalidOrder
+		}
+		colors[key] = g.smallestFreeColor(key, colors)
+	}
+	return colors, nil
+}
+
+// DSaturColoring colors the nodes of the graph using Brélaz' DSatur heuristic.
+// The next node to color is the node with the most distinct colors among its
+// neighbors, ties are broken by degree and then by key. Colors are numbered
+// from zero. Self-loops are ignored.
+func (g *Graph) DSaturColoring() map[string]int {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+
+	colors, _ := g.dsatur()
+	return colors
+}
+
+// dsatur implements DSaturColoring without acquiring the lock. It also returns
+// the order in which the nodes have been colored.
+func (g *Graph) dsatur() (map[string]int, []string) {
+	keys := g.sortedNodes()
+	colors := make(map[string]int, len(keys))
+	saturation := make(map[string]map[int]bool, len(keys))
+	adjacent := make(map[string][]string, len(keys))
+	for _, key := range keys {
+		saturation[key] = make(map[int]bool)
+		adjacent[key] = g.adjacent(key)
+	}
+	order := make([]string, 0, len(keys))
+	for len(order) < len(keys) {
+		next := ""
+		found := false
+		for _, key := range keys {
+			if _, ok := colors[key]; ok {
+				continue
+			}
+			if !found ||
+				len(saturation[key]) > len(saturation[next]) ||
+				len(saturation[key]) == len(saturation[next]) &&
+					len(adjacent[key]) > len(adjacent[next]) {
+				next = key
+				found = true
+			}
+		}
+		color := g.smallestFreeColor(next, colors)
+		colors[next] = color
+		order = append(order, next)
+		for _, to := range adjacent[next] {
+			saturation[to][color] = true
+		}
+	}
+	return colors, order
+}
+
+// ChromaticNumber returns the smallest number of colors needed to color the
+// nodes of the graph so that no edge connects nodes of the same color, along
+// with such a coloring. The search is exact and takes exponential time in the
+// worst case, ErrorTooManyNodes is returned if the graph has more than limit
+// nodes. Self-loops are ignored.
+func (g *Graph) ChromaticNumber(limit int) (int, map[string]int, error) {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+
+	if len(g.nodes) > limit {
+		return 0, nil, ErrorTooManyNodes
+	}
+
+	// DSatur provides an upper bound and a good order for the search
+	best, order := g.dsatur()
+	k := 0
+	for _, color := range best {
+		if color+1 > k {
+			k = color + 1
+		}
+	}
+	index := make(map[string]int, len(order))
+	for i, key := range order {
+		index[key] = i
+	}
+	// earlier lists the neighbors of every node that are colored before it
+	earlier := make([][]int, len(order))
+	for i, key := range order {
+		for _, to := range g.adjacent(key) {
+			if index[to] < i {
+				earlier[i] = append(earlier[i], index[to])
+			}
+		}
+		sort.Ints(earlier[i])
+	}
+
+	colors := make([]int, len(order))
+	var search func(i, used, limit int) bool
+	search = func(i, used, limit int) bool {
+		if i == len(order) {
+			return true
+		}
+		// a node may open at most one new color, which avoids trying colorings
+		// that only differ by a permutation of colors
+		for color := 0; color <= used && color < limit; color++ {
+			conflict := false
+			for _, j := range earlier[i] {
+				if colors[j] == color {
+					conflict = true
+					break
+				}
+			}
+			if conflict {
+				continue
+			}
+			colors[i] = color
+			next := used
+			if color == used {
+				next++
+			}
+			if search(i+1, next, limit) {
+				return true
+			}
+		}
+		return false
+	}
+	for k > 0 && search(0, 0, k-1) {
+		k--
+		best = make(map[string]int, len(order))
+		for i, key := range order {
+			best[key] = colors[i]
+		}
+	}
+	return k, best, nil
+}
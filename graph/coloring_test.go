@@ -0,0 +1,267 @@
+package graph
+
+import (
+	"testing"
+)
+
+// wheel returns a wheel graph with a hub connected to a cycle of n rim nodes
+func wheel(n int) *Graph {
+	g := New()
+	g.NewNode("hub", nil)
+	for i := 0; i < n; i++ {
+		g.NewNode(string(rune('a'+i)), nil)
+	}
+	for i := 0; i < n; i++ {
+		g.NewEdge("hub", string(rune('a'+i)))
+		g.NewEdge(string(rune('a'+i)), string(rune('a'+(i+1)%n)))
+	}
+	return g
+}
+
+// proper reports whether no edge connects two nodes of the same color and
+// every node has a color
+func proper(g *Graph, colors map[string]int) bool {
+	if len(colors) != len(g.nodes) {
+		return false
+	}
+	for from := range g.nodes {
+		for _, to := range g.adjacent(from) {
+			if colors[from] == colors[to] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// countColors returns the number of distinct colors in a coloring
+func countColors(colors map[string]int) int {
+	distinct := make(map[int]bool)
+	for _, color := range colors {
+		distinct[color] = true
+	}
+	return len(distinct)
+}
+
+func TestGraphIsBipartite(t *testing.T) {
+	t.Run("bipartite graph", func(t *testing.T) {
+		ok, partitions, cycle := ladder().IsBipartite()
+		if !ok {
+			t.Fatalf("expected `%v` got `%v`", true, ok)
+		}
+		if cycle != nil {
+			t.Errorf("expected `%v` got `%v`", nil, cycle)
+		}
+		expected := [2][]string{{"a", "d", "e", "z"}, {"b", "c", "f"}}
+		if !equal(expected[0], partitions[0]) || !equal(expected[1], partitions[1]) {
+			t.Errorf("expected `%v` got `%v`", expected, partitions)
+		}
+	})
+	t.Run("odd cycle", func(t *testing.T) {
+		g := ladder()
+		g.NewEdge("d", "e")
+		ok, _, cycle := g.IsBipartite()
+		if ok {
+			t.Fatalf("expected `%v` got `%v`", false, ok)
+		}
+		expected := []string{"d", "b", "e"}
+		if !equal(expected, cycle) {
+			t.Errorf("expected `%v` got `%v`", expected, cycle)
+		}
+	})
+	t.Run("long odd cycle", func(t *testing.T) {
+		g := New()
+		keys := []string{"a", "b", "c", "d", "e", "f", "g"}
+		for _, key := range keys {
+			g.NewNode(key, nil)
+		}
+		for i := range keys {
+			g.NewEdge(keys[i], keys[(i+1)%len(keys)])
+		}
+		_, _, cycle := g.IsBipartite()
+		if len(cycle) != len(keys) {
+			t.Fatalf("expected cycle of length `%v` got `%v`", len(keys), cycle)
+		}
+		for i := range cycle {
+			if !g.edges[cycle[i]][cycle[(i+1)%len(cycle)]] {
+				t.Errorf("expected edge `%v`-`%v` not found", cycle[i],
+					cycle[(i+1)%len(cycle)])
+			}
+		}
+	})
+	t.Run("self-loop", func(t *testing.T) {
+		g := New()
+		g.NewNode("a", nil)
+		g.NewEdge("a", "a")
+		ok, _, cycle := g.IsBipartite()
+		if ok || !equal([]string{"a"}, cycle) {
+			t.Errorf("expected `%v` got `%v`", []string{"a"}, cycle)
+		}
+	})
+}
+
+func TestGraphGreedyColoring(t *testing.T) {
+	t.Run("good order", func(t *testing.T) {
+		g := ladder()
+		colors, err := g.GreedyColoring([]string{"a", "b", "c", "d", "e", "f", "z"})
+		if err != nil {
+			t.Errorf("expected `%v` got `%v`", nil, err)
+		}
+		if !proper(g, colors) {
+			t.Errorf("coloring `%v` is not proper", colors)
+		}
+		if got := countColors(colors); got != 2 {
+			t.Errorf("expected `%v` colors got `%v`", 2, got)
+		}
+	})
+	t.Run("bad order", func(t *testing.T) {
+		// a crown graph needs n colors when colored in pair order
+		g := New()
+		keys := []string{"a1", "b1", "a2", "b2", "a3", "b3"}
+		for _, key := range keys {
+			g.NewNode(key, nil)
+		}
+		for _, a := range []string{"a1", "a2", "a3"} {
+			for _, b := range []string{"b1", "b2", "b3"} {
+				if a[1] != b[1] {
+					g.NewEdge(a, b)
+				}
+			}
+		}
+		colors, _ := g.GreedyColoring(keys)
+		if !proper(g, colors) {
+			t.Errorf("coloring `%v` is not proper", colors)
+		}
+		if got := countColors(colors); got != 3 {
+			t.Errorf("expected `%v` colors got `%v`", 3, got)
+		}
+	})
+	t.Run("invalid order", func(t *testing.T) {
+		g := New()
+		g.NewNode("a", nil)
+		g.NewNode("b", nil)
+		tt := []struct {
+			order    []string
+			expected error
+		}{
+			{order: []string{"a"}, expected: ErrorInvalidOrder},
+			{order: []string{"a", "a"}, expected: ErrorInvalidOrder},
+			{order: []string{"a", "x"}, expected: ErrorNodeNotFound},
+		}
+		for _, tc := range tt {
+			if _, err := g.GreedyColoring(tc.order); err != tc.expected {
+				t.Errorf("order `%v`: expected `%v` got `%v`", tc.order, tc.expected,
+					err)
+			}
+		}
+	})
+}
+
+func TestGraphDSaturColoring(t *testing.T) {
+	tt := []struct {
+		name     string
+		g        *Graph
+		expected int
+	}{
+		{name: "empty graph", g: New(), expected: 0},
+		{name: "bipartite graph", g: ladder(), expected: 2},
+		{name: "even wheel", g: wheel(6), expected: 3},
+		{name: "odd wheel", g: wheel(5), expected: 4},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			colors := tc.g.DSaturColoring()
+			if !proper(tc.g, colors) {
+				t.Errorf("coloring `%v` is not proper", colors)
+			}
+			if got := countColors(colors); got != tc.expected {
+				t.Errorf("expected `%v` colors got `%v`", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestGraphChromaticNumber(t *testing.T) {
+	// the Petersen graph has chromatic number 3
+	petersen := New()
+	for i := 0; i < 10; i++ {
+		petersen.NewNode(string(rune('a'+i)), nil)
+	}
+	for i := 0; i < 5; i++ {
+		petersen.NewEdge(string(rune('a'+i)), string(rune('a'+(i+1)%5)))
+		petersen.NewEdge(string(rune('f'+i)), string(rune('f'+(i+2)%5)))
+		petersen.NewEdge(string(rune('a'+i)), string(rune('f'+i)))
+	}
+	single := New()
+	single.NewNode("a", nil)
+	single.NewEdge("a", "a")
+
+	tt := []struct {
+		name     string
+		g        *Graph
+		expected int
+	}{
+		{name: "empty graph", g: New(), expected: 0},
+		{name: "single node", g: single, expected: 1},
+		{name: "bipartite graph", g: ladder(), expected: 2},
+		{name: "odd wheel", g: wheel(7), expected: 4},
+		{name: "petersen graph", g: petersen, expected: 3},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			got, colors, err := tc.g.ChromaticNumber(16)
+			if err != nil {
+				t.Errorf("expected `%v` got `%v`", nil, err)
+			}
+			if got != tc.expected {
+				t.Errorf("expected `%v` got `%v`", tc.expected, got)
+			}
+			if !proper(tc.g, colors) {
+				t.Errorf("coloring `%v` is not proper", colors)
+			}
+			if n := countColors(colors); n != got {
+				t.Errorf("expected `%v` colors got `%v`", got, n)
+			}
+		})
+	}
+	t.Run("exhaustive check", func(t *testing.T) {
+		keys := []string{"a", "b", "c", "d", "e", "f", "g"}
+		for seed := 1; seed <= 20; seed++ {
+			g := New()
+			for _, key := range keys {
+				g.NewNode(key, nil)
+			}
+			for i := range keys {
+				for j := i + 1; j < len(keys); j++ {
+					if (seed*(i+3)*(j+5))%7 < 4 {
+						g.NewEdge(keys[i], keys[j])
+					}
+				}
+			}
+			k, _, _ := g.ChromaticNumber(len(keys))
+			if k < 2 {
+				continue
+			}
+			// no coloring with fewer colors may exist
+			n := 1
+			for range keys {
+				n *= k - 1
+			}
+			for c := 0; c < n; c++ {
+				colors := make(map[string]int)
+				for i, rest := 0, c; i < len(keys); i, rest = i+1, rest/(k-1) {
+					colors[keys[i]] = rest % (k - 1)
+				}
+				if proper(g, colors) {
+					t.Fatalf("expected no coloring with `%v` colors, found `%v`",
+						k-1, colors)
+				}
+			}
+		}
+	})
+	t.Run("too many nodes", func(t *testing.T) {
+		if _, _, err := petersen.ChromaticNumber(9); err != ErrorTooManyNodes {
+			t.Errorf("expected `%v` got `%v`", ErrorTooManyNodes, err)
+		}
+	})
+}
@@ -0,0 +1,65 @@
+package graph
+
+import "sort"
+
+// ConnectedComponents returns the connected components of the graph. Every
+// component lists the keys of its nodes in lexical order, components are
+// ordered by their lexically smallest key.
+func (g *Graph) ConnectedComponents() [][]string {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+
+	return g.connectedComponents()
+}
+
+// connectedComponents returns the connected components of the graph without
+// acquiring the lock
+func (g *Graph) connectedComponents() [][]string {
+	index := make(map[string]int)
+	var components [][]string
+	for _, key := range g.sortedNodes() {
+		root, _ := g.components.Find(key)
+		i, ok := index[root]
+		if !ok {
+			i = len(components)
+			index[root] = i
+			components = append(components, nil)
+		}
+		components[i] = append(components[i], key)
+	}
+	return components
+}
+
+// Connected returns true if there is a path between two nodes
+func (g *Graph) Connected(a, b string) (bool, error) {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+
+	if _, ok := g.nodes[a]; !ok {
+		return false, ErrorNodeNotFound
+	}
+	if _, ok := g.nodes[b]; !ok {
+		return false, ErrorNodeNotFound
+	}
+	return g.components.Connected(a, b)
+}
+
+// ComponentOf returns the keys of all nodes in the connected component of the
+// node identified by key, including the node itself, in lexical order
+func (g *Graph) ComponentOf(key string) ([]string, error) {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+
+	if _, ok := g.nodes[key]; !ok {
+		return nil, ErrorNodeNotFound
+	}
+	root, _ := g.components.Find(key)
+	var component []string
+	for other := range g.nodes {
+		if r, _ := g.components.Find(other); r == root {
+			component = append(component, other)
+		}
+	}
+	sort.Strings(component)
+	return component, nil
+}
@@ -0,0 +1,106 @@
+package graph
+
+import (
+	"testing"
+)
+
+// islands returns a graph with three connected components
+func islands() *Graph {
+	g := New()
+	for _, nd := range nodes {
+		g.NewNode(nd.key, nd.value)
+	}
+	for _, e := range edges {
+		g.NewEdge(e.from, e.to)
+	}
+	g.NewNode("x", nil)
+	g.NewNode("y", nil)
+	g.NewEdge("y", "x")
+	return g
+}
+
+func TestGraphConnectedComponents(t *testing.T) {
+	t.Run("empty graph", func(t *testing.T) {
+		if got := New().ConnectedComponents(); len(got) != 0 {
+			t.Errorf("expected no components, got `%v`", got)
+		}
+	})
+	t.Run("multiple components", func(t *testing.T) {
+		g := islands()
+		expected := [][]string{
+			{"eleven", "foo", "friends", "scary"},
+			{"ocean's"},
+			{"x", "y"},
+		}
+		got := g.ConnectedComponents()
+		if len(got) != len(expected) {
+			t.Fatalf("expected `%v` components got `%v`", len(expected), len(got))
+		}
+		for i := range expected {
+			if !equal(expected[i], got[i]) {
+				t.Errorf("expected `%v` got `%v`", expected[i], got[i])
+			}
+		}
+	})
+	t.Run("incremental maintenance", func(t *testing.T) {
+		g := islands()
+		g.NewEdge("ocean's", "x")
+		g.NewEdge("scary", "y")
+		if got := g.ConnectedComponents(); len(got) != 1 {
+			t.Errorf("expected `%v` components got `%v`", 1, len(got))
+		}
+	})
+}
+
+func TestGraphConnected(t *testing.T) {
+	t.Run("existing nodes", func(t *testing.T) {
+		g := islands()
+		tt := []struct {
+			a, b     string
+			expected bool
+		}{
+			{a: "foo", b: "scary", expected: true},
+			{a: "x", b: "y", expected: true},
+			{a: "foo", b: "x", expected: false},
+			{a: "ocean's", b: "ocean's", expected: true},
+		}
+		for _, tc := range tt {
+			got, err := g.Connected(tc.a, tc.b)
+			if err != nil {
+				t.Errorf("expected `%v` got `%v`", nil, err)
+			}
+			if got != tc.expected {
+				t.Errorf("`%v` and `%v`: expected `%v` got `%v`", tc.a, tc.b,
+					tc.expected, got)
+			}
+		}
+	})
+	t.Run("unknown nodes", func(t *testing.T) {
+		g := islands()
+		if _, err := g.Connected("foo", "unknown"); err != ErrorNodeNotFound {
+			t.Errorf("expected `%v` got `%v`", ErrorNodeNotFound, err)
+		}
+		if _, err := g.Connected("unknown", "foo"); err != ErrorNodeNotFound {
+			t.Errorf("expected `%v` got `%v`", ErrorNodeNotFound, err)
+		}
+	})
+}
+
+func TestGraphComponentOf(t *testing.T) {
+	t.Run("existing nodes", func(t *testing.T) {
+		g := islands()
+		got, err := g.ComponentOf("friends")
+		if err != nil {
+			t.Errorf("expected `%v` got `%v`", nil, err)
+		}
+		expected := []string{"eleven", "foo", "friends", "scary"}
+		if !equal(expected, got) {
+			t.Errorf("expected `%v` got `%v`", expected, got)
+		}
+	})
+	t.Run("unknown node", func(t *testing.T) {
+		if _, err := New().ComponentOf("foo"); err != ErrorNodeNotFound {
+			t.Errorf("expected `%v` got `%v`", ErrorNodeNotFound, err)
+		}
+	})
+}
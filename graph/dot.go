@@ -0,0 +1,146 @@
+package graph
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/danrl/golibby/internal/dot"
+)
+
+// DOTOptions controls how a graph is written in Graphviz DOT format
+type DOTOptions struct {
+	// Name is the name of the graph, the graph is anonymous if empty
+	Name string
+	// Label returns the label of a node. Nodes are written without label if
+	// Label is nil. ValueLabel uses node values as labels.
+	Label func(key string, value interface{}) string
+	// Weight returns the weight of an edge and whether the edge has a weight
+	// at all. Weights are written as edge labels. EdgeWeight uses the weights
+	// assigned to the edges of the graph.
+	Weight func(from, to string) (float64, bool)
+	// Highlight is a path of node keys. Nodes and edges along the path are
+	// drawn in red.
+	Highlight []string
+}
+
+// ValueLabel is a label function for DOTOptions that labels nodes with their
+// values
+func ValueLabel(key string, value interface{}) string {
+	return fmt.Sprintf("%v", value)
+}
+
+// EdgeWeight returns a weight function for DOTOptions that reports the weights
+// of the graph's edges. Edges without an explicitly assigned weight are written
+// without label.
+func (g *Graph) EdgeWeight() func(from, to string) (float64, bool) {
+	return func(from, to string) (float64, bool) {
+		g.lock.RLock()
+		defer g.lock.RUnlock()
+
+		weight, ok := g.weights[from][to]
+		return weight, ok
+	}
+}
+
+// WriteDOT writes the graph in Graphviz DOT format. Nodes and edges are written
+// in lexical order.
+func (g *Graph) WriteDOT(w io.Writer, opts DOTOptions) error {
+	onPath := make(map[string]bool)
+	pathEdges := make(map[[2]string]bool)
+	for i, key := range opts.Highlight {
+		onPath[key] = true
+		if i > 0 {
+			pathEdges[[2]string{opts.Highlight[i-1], key}] = true
+			pathEdges[[2]string{key, opts.Highlight[i-1]}] = true
+		}
+	}
+
+	// callbacks may call methods of the graph, so they run on a copy taken
+	// under the lock
+	g.lock.RLock()
+	keys := g.sortedNodes()
+	values := make([]interface{}, len(keys))
+	var edges [][2]string
+	for i, from := range keys {
+		values[i] = g.nodes[from]
+		for _, to := range g.sortedEdges(from) {
+			edges = append(edges, [2]string{from, to})
+		}
+	}
+	g.lock.RUnlock()
+
+	var out bytes.Buffer
+	out.WriteString("graph ")
+	if opts.Name != "" {
+		out.WriteString(dot.Quote(opts.Name) + " ")
+	}
+	out.WriteString("{\n")
+	for i, key := range keys {
+		var attrs []string
+		if opts.Label != nil {
+			attrs = append(attrs, "label", opts.Label(key, values[i]))
+		}
+		if onPath[key] {
+			attrs = append(attrs, "color", "red")
+		}
+		out.WriteString(fmt.Sprintf("\t%s%s;\n", dot.Quote(key), dot.Attrs(attrs...)))
+	}
+	for _, e := range edges {
+		var attrs []string
+		if opts.Weight != nil {
+			if weight, ok := opts.Weight(e[0], e[1]); ok {
+				attrs = append(attrs, "label",
+					strconv.FormatFloat(weight, 'g', -1, 64))
+			}
+		}
+		if pathEdges[e] {
+			attrs = append(attrs, "color", "red")
+		}
+		out.WriteString(fmt.Sprintf("\t%s -- %s%s;\n", dot.Quote(e[0]),
+			dot.Quote(e[1]), dot.Attrs(attrs...)))
+	}
+	out.WriteString("}\n")
+
+	_, err := out.WriteTo(w)
+	return err
+}
+
+// ReadDOT reads an undirected graph in Graphviz DOT format. Nodes with a
+// `label` attribute get the label as value, all other nodes have a nil value.
+// Edges with a numeric `weight` attribute, or a numeric `label` attribute if
+// there is no `weight`, get the number as weight. All other attributes are
+// ignored. ErrorInvalidFormat is returned if the input
+// is not a valid DOT document describing an undirected graph.
+func ReadDOT(r io.Reader) (*Graph, error) {
+	d, err := dot.Parse(r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrorInvalidFormat, err)
+	}
+	if d.Directed {
+		return nil, fmt.Errorf("%w: graph is directed", ErrorInvalidFormat)
+	}
+	g := New()
+	for _, nd := range d.Nodes {
+		var value interface{}
+		if label, ok := nd.Attrs["label"]; ok {
+			value = label
+		}
+		g.NewNode(nd.ID, value)
+	}
+	for _, e := range d.Edges {
+		g.NewEdge(e.From, e.To)
+		label, ok := e.Attrs["weight"]
+		if !ok {
+			label, ok = e.Attrs["label"]
+		}
+		if !ok {
+			continue
+		}
+		if weight, err := strconv.ParseFloat(label, 64); err == nil && validWeight(weight) {
+			g.setWeight(e.From, e.To, weight)
+		}
+	}
+	return g, nil
+}
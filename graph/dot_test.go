@@ -0,0 +1,168 @@
+package graph
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestGraphWriteDOT(t *testing.T) {
+	t.Run("callbacks using the graph", func(t *testing.T) {
+		g := New()
+		g.NewNode("a", "foo")
+		g.NewNode("b", "bar")
+		g.NewEdge("a", "b")
+		var out bytes.Buffer
+		err := g.WriteDOT(&out, DOTOptions{
+			Label: func(key string, _ interface{}) string {
+				// a writer waiting for the lock must not block readers
+				// called from within the callback
+				done := make(chan struct{})
+				go func() {
+					g.UpdateValue(key, key)
+					close(done)
+				}()
+				<-done
+				value, _ := g.Value(key)
+				return value.(string)
+			},
+			Weight: g.EdgeWeight(),
+		})
+		if err != nil {
+			t.Errorf("expected `%v` got `%v`", nil, err)
+		}
+		if got := out.String(); !strings.Contains(got, `"a" [label="a"]`) {
+			t.Errorf("unexpected output `%v`", got)
+		}
+	})
+	t.Run("empty graph", func(t *testing.T) {
+		var out bytes.Buffer
+		err := New().WriteDOT(&out, DOTOptions{})
+		if err != nil {
+			t.Errorf("expected `%v` got `%v`", nil, err)
+		}
+		expected := "graph {\n}\n"
+		if got := out.String(); got != expected {
+			t.Errorf("expected `%v` got `%v`", expected, got)
+		}
+	})
+	t.Run("regular graph", func(t *testing.T) {
+		g := New()
+		for _, nd := range nodes {
+			g.NewNode(nd.key, nd.value)
+		}
+		for _, e := range edges {
+			g.NewEdge(e.from, e.to)
+		}
+		var out bytes.Buffer
+		err := g.WriteDOT(&out, DOTOptions{
+			Name:  "test",
+			Label: ValueLabel,
+			Weight: func(from, to string) (float64, bool) {
+				return 2, from == "eleven" && to == "foo"
+			},
+			Highlight: []string{"scary", "eleven", "friends"},
+		})
+		if err != nil {
+			t.Errorf("expected `%v` got `%v`", nil, err)
+		}
+		expected := `graph "test" {
+	"eleven" [label="11", color="red"];
+	"foo" [label="bar"];
+	"friends" [label="🤩", color="red"];
+	"ocean's" [label="11!!!"];
+	"scary" [label="1337", color="red"];
+	"eleven" -- "foo" [label="2"];
+	"eleven" -- "friends" [color="red"];
+	"eleven" -- "scary" [color="red"];
+}
+`
+		if got := out.String(); got != expected {
+			t.Errorf("expected `%v` got `%v`", expected, got)
+		}
+	})
+}
+
+func TestReadDOT(t *testing.T) {
+	t.Run("round trip", func(t *testing.T) {
+		g := New()
+		for _, nd := range nodes {
+			g.NewNode(nd.key, nd.value)
+		}
+		for _, e := range edges {
+			g.NewEdge(e.from, e.to)
+		}
+		var out bytes.Buffer
+		g.WriteDOT(&out, DOTOptions{Label: ValueLabel})
+		r, err := ReadDOT(&out)
+		if err != nil {
+			t.Fatalf("expected `%v` got `%v`", nil, err)
+		}
+		if len(r.nodes) != len(nodes) {
+			t.Errorf("expected `%v` nodes, got `%v`", len(nodes), len(r.nodes))
+		}
+		for _, nd := range nodes {
+			if value := r.nodes[nd.key]; value != ValueLabel(nd.key, nd.value) {
+				t.Errorf("expected node value `%v`, got `%v`", nd.value, value)
+			}
+		}
+		for _, e := range edges {
+			if !r.edges[e.from][e.to] || !r.edges[e.to][e.from] {
+				t.Errorf("expected edge `%v`-`%v` not found.", e.from, e.to)
+			}
+		}
+	})
+	t.Run("weights", func(t *testing.T) {
+		g := New()
+		g.NewNode("a", nil)
+		g.NewNode("b", nil)
+		g.NewNode("c", nil)
+		g.NewEdgeWithWeight("a", "b", -0.5)
+		g.NewEdge("b", "c")
+		var out bytes.Buffer
+		g.WriteDOT(&out, DOTOptions{Weight: g.EdgeWeight()})
+		r, err := ReadDOT(&out)
+		if err != nil {
+			t.Fatalf("expected `%v` got `%v`", nil, err)
+		}
+		if got, _ := r.Weight("a", "b"); got != -0.5 {
+			t.Errorf("expected `%v` got `%v`", -0.5, got)
+		}
+		if _, ok := r.weights["b"]["c"]; ok {
+			t.Errorf("unexpected weight for edge `%v`-`%v`", "b", "c")
+		}
+		r, _ = ReadDOT(strings.NewReader(
+			`graph { a -- b [weight=3, label=7]; b -- c [label="x"] }`))
+		if got, _ := r.Weight("a", "b"); got != 3 {
+			t.Errorf("expected `%v` got `%v`", 3, got)
+		}
+		if got, _ := r.Weight("b", "c"); got != DefaultWeight {
+			t.Errorf("expected `%v` got `%v`", DefaultWeight, got)
+		}
+		r, _ = ReadDOT(strings.NewReader(`graph { a -- b [weight=inf]; b -- c [weight=nan] }`))
+		if got, _ := r.Weight("a", "b"); got != DefaultWeight {
+			t.Errorf("expected `%v` got `%v`", DefaultWeight, got)
+		}
+		if got, _ := r.Weight("b", "c"); got != DefaultWeight {
+			t.Errorf("expected `%v` got `%v`", DefaultWeight, got)
+		}
+	})
+	t.Run("nodes without label", func(t *testing.T) {
+		r, err := ReadDOT(strings.NewReader(`graph { a -- b }`))
+		if err != nil {
+			t.Fatalf("expected `%v` got `%v`", nil, err)
+		}
+		if value, ok := r.nodes["a"]; !ok || value != nil {
+			t.Errorf("expected node `%v` with value `%v`", "a", nil)
+		}
+	})
+	t.Run("invalid input", func(t *testing.T) {
+		for _, in := range []string{`graph {`, `digraph { a -> b }`} {
+			_, err := ReadDOT(strings.NewReader(in))
+			if !errors.Is(err, ErrorInvalidFormat) {
+				t.Errorf("expected `%v` got `%v`", ErrorInvalidFormat, err)
+			}
+		}
+	})
+}
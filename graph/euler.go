@@ -0,0 +1,34 @@
+package graph
+
+import (
+	"github.com/danrl/golibby/graphalg"
+)
+
+// EulerianCircuit returns a closed walk that uses every edge exactly once. The
+// returned error wraps graphalg.ErrorNotEulerian and explains why there is no
+// such walk. See graphalg.EulerianCircuit.
+func (g *Graph) EulerianCircuit() ([]string, error) {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+
+	return graphalg.EulerianCircuit(view{g: g})
+}
+
+// EulerianPath returns a walk that uses every edge exactly once. The returned
+// error wraps graphalg.ErrorNotEulerian and explains why there is no such
+// walk. See graphalg.EulerianPath.
+func (g *Graph) EulerianPath() ([]string, error) {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+
+	return graphalg.EulerianPath(view{g: g})
+}
+
+// HamiltonianPath returns a path that visits every node exactly once. Large
+// graphs are searched for at most budget steps. See graphalg.HamiltonianPath.
+func (g *Graph) HamiltonianPath(budget int) ([]string, error) {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+
+	return graphalg.HamiltonianPath(view{g: g}, budget)
+}
@@ -0,0 +1,67 @@
+package graph
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/danrl/golibby/graphalg"
+)
+
+func TestGraphEulerianCircuit(t *testing.T) {
+	t.Run("cycle with self-loop", func(t *testing.T) {
+		g := New()
+		for _, key := range []string{"a", "b", "c"} {
+			g.NewNode(key, nil)
+		}
+		g.NewEdge("a", "b")
+		g.NewEdge("b", "c")
+		g.NewEdge("c", "a")
+		g.NewEdge("b", "b")
+		got, err := g.EulerianCircuit()
+		if err != nil {
+			t.Errorf("expected `%v` got `%v`", nil, err)
+		}
+		expected := []string{"a", "b", "b", "c", "a"}
+		if !equal(expected, got) {
+			t.Errorf("expected `%v` got `%v`", expected, got)
+		}
+	})
+	t.Run("odd degrees", func(t *testing.T) {
+		_, err := ladder().EulerianCircuit()
+		if !errors.Is(err, graphalg.ErrorNotEulerian) {
+			t.Errorf("expected `%v` got `%v`", graphalg.ErrorNotEulerian, err)
+		}
+	})
+}
+
+func TestGraphEulerianPath(t *testing.T) {
+	g := ladder()
+	got, err := g.EulerianPath()
+	if err != nil {
+		t.Errorf("expected `%v` got `%v`", nil, err)
+	}
+	expected := []string{"b", "a", "c", "d", "b", "e", "f"}
+	if !equal(expected, got) {
+		t.Errorf("expected `%v` got `%v`", expected, got)
+	}
+}
+
+func TestGraphHamiltonianPath(t *testing.T) {
+	t.Run("isolated node", func(t *testing.T) {
+		if _, err := ladder().HamiltonianPath(0); err != graphalg.ErrorNotHamiltonian {
+			t.Errorf("expected `%v` got `%v`", graphalg.ErrorNotHamiltonian, err)
+		}
+	})
+	t.Run("connected graph", func(t *testing.T) {
+		g := ladder()
+		g.NewEdge("f", "z")
+		got, err := g.HamiltonianPath(0)
+		if err != nil {
+			t.Errorf("expected `%v` got `%v`", nil, err)
+		}
+		expected := []string{"z", "f", "e", "b", "d", "c", "a"}
+		if !equal(expected, got) {
+			t.Errorf("expected `%v` got `%v`", expected, got)
+		}
+	})
+}
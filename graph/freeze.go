@@ -0,0 +1,31 @@
+package graph
+
+import (
+	"github.com/danrl/golibby/csr"
+)
+
+// Freeze returns an immutable copy of the nodes, values and edges of the graph
+// in compressed sparse row format. Edge weights are not copied.
+func (g *Graph) Freeze() *csr.Graph {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+
+	keys := g.sortedNodes()
+	values := make([]interface{}, len(keys))
+	ids := make(map[string]int, len(keys))
+	for i, key := range keys {
+		values[i] = g.nodes[key]
+		ids[key] = i
+	}
+	var buf []int
+	c, _ := csr.NewGraph(keys, values, func(id int) []int {
+		buf = buf[:0]
+		for to, active := range g.edges[keys[id]] {
+			if active {
+				buf = append(buf, ids[to])
+			}
+		}
+		return buf
+	})
+	return c
+}
@@ -0,0 +1,80 @@
+package graph
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/danrl/golibby/graphalg"
+)
+
+func TestGraphFreeze(t *testing.T) {
+	g := ladder()
+	g.UpdateValue("a", 42)
+	g.NewEdge("f", "f")
+	c := g.Freeze()
+	if got := c.Nodes(); !equal(g.sortedNodes(), got) {
+		t.Errorf("expected `%v` got `%v`", g.sortedNodes(), got)
+	}
+	for _, key := range g.sortedNodes() {
+		expected, _ := g.Neighbors(key)
+		got, err := c.Neighbors(key)
+		if err != nil {
+			t.Errorf("expected `%v` got `%v`", nil, err)
+		}
+		if !equal(expected, got) {
+			t.Errorf("node `%v`: expected `%v` got `%v`", key, expected, got)
+		}
+	}
+	if value, _ := c.Value("a"); value != 42 {
+		t.Errorf("expected `%v` got `%v`", 42, value)
+	}
+	// the frozen graph does not follow changes
+	g.NewEdge("a", "z")
+	if c.HasEdge("a", "z") {
+		t.Errorf("unexpected edge `%v`-`%v`", "a", "z")
+	}
+	expected, _ := g.ShortestPath("c", "f")
+	got, _ := graphalg.ShortestPath(c, "c", "f")
+	if !equal(expected, got) {
+		t.Errorf("expected `%v` got `%v`", expected, got)
+	}
+}
+
+// grid returns a graph of n times n nodes connected to their horizontal and
+// vertical neighbors
+func grid(n int) *Graph {
+	g := New()
+	for i := 0; i < n*n; i++ {
+		g.NewNode(strconv.Itoa(i), nil)
+	}
+	for i := 0; i < n*n; i++ {
+		if i%n+1 < n {
+			g.NewEdge(strconv.Itoa(i), strconv.Itoa(i+1))
+		}
+		if i+n < n*n {
+			g.NewEdge(strconv.Itoa(i), strconv.Itoa(i+n))
+		}
+	}
+	return g
+}
+
+func BenchmarkGraphNeighbors(b *testing.B) {
+	g := grid(100)
+	keys := g.sortedNodes()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		for _, key := range keys {
+			g.Neighbors(key)
+		}
+	}
+}
+
+func BenchmarkFrozenGraphNeighborIDs(b *testing.B) {
+	c := grid(100).Freeze()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		for id := 0; id < c.Len(); id++ {
+			_ = c.NeighborIDs(id)
+		}
+	}
+}
@@ -5,7 +5,11 @@ package graph
 import (
 	"bytes"
 	"fmt"
+	"sort"
 	"sync"
+
+	"github.com/danrl/golibby/graphalg"
+	"github.com/danrl/golibby/unionfind"
 )
 
 var (
@@ -13,20 +17,37 @@ var (
 	ErrorNodeNotFound = fmt.Errorf("node not found")
 	// ErrorNodeAlreadyExists is returned when trying to create duplicate nodes
 	ErrorNodeAlreadyExists = fmt.Errorf("node already exists")
+	// ErrorEdgeNotFound is returned when trying to access a non-existent edge
+	ErrorEdgeNotFound = fmt.Errorf("edge not found")
+	// ErrorInvalidWeight is returned when trying to assign an infinite or NaN
+	// weight to an edge
+	ErrorInvalidWeight = fmt.Errorf("invalid weight")
+	// ErrorInvalidFormat is returned when decoding a graph from malformed input
+	ErrorInvalidFormat = fmt.Errorf("invalid format")
 )
 
-// Graph holds a graph data structure
+// Graph holds a graph data structure. The connected components of the graph
+// are maintained incrementally in `components` as nodes and edges are added.
+// Edge weights are stored in both directions in `weights`, edges without an
+// entry have DefaultWeight.
 type Graph struct {
-	lock  sync.RWMutex
-	nodes map[string]interface{}
-	edges map[string]map[string]bool
+	lock       sync.RWMutex
+	nodes      map[string]interface{}
+	edges      map[string]map[string]bool
+	weights    map[string]map[string]float64
+	components *unionfind.UnionFind
 }
 
+// Graph implements the read-only interface of the graph algorithms
+var _ graphalg.Graph = (*Graph)(nil)
+
 // New initializes a new graph
 func New() *Graph {
 	return &Graph{
-		nodes: make(map[string]interface{}),
-		edges: make(map[string]map[string]bool),
+		nodes:      make(map[string]interface{}),
+		edges:      make(map[string]map[string]bool),
+		weights:    make(map[string]map[string]float64),
+		components: &unionfind.UnionFind{},
 	}
 }
 
@@ -40,6 +61,7 @@ func (g *Graph) NewNode(key string, value interface{}) error {
 	}
 	g.nodes[key] = value
 	g.edges[key] = make(map[string]bool)
+	g.components.Add(key)
 
 	return nil
 }
@@ -73,6 +95,11 @@ func (g *Graph) NewEdge(from, to string) error {
 	g.lock.Lock()
 	defer g.lock.Unlock()
 
+	return g.newEdge(from, to)
+}
+
+// newEdge adds an edge between to nodes without acquiring the lock
+func (g *Graph) newEdge(from, to string) error {
 	if _, ok := g.nodes[from]; !ok {
 		return ErrorNodeNotFound
 	}
@@ -82,6 +109,7 @@ func (g *Graph) NewEdge(from, to string) error {
 
 	g.edges[from][to] = true
 	g.edges[to][from] = true
+	g.components.Union(from, to)
 	return nil
 }
 
@@ -90,6 +118,8 @@ func (g *Graph) Edges(from string) ([]string, error) {
 	var edges []string
 
 	g.lock.RLock()
+	defer g.lock.RUnlock()
+
 	if _, ok := g.nodes[from]; !ok {
 		return edges, ErrorNodeNotFound
 	}
@@ -98,11 +128,30 @@ func (g *Graph) Edges(from string) ([]string, error) {
 			edges = append(edges, to)
 		}
 	}
-	g.lock.RUnlock()
 
 	return edges, nil
 }
 
+// Neighbors returns the keys of nodes that are directly connected to the node
+// in lexical order
+func (g *Graph) Neighbors(key string) ([]string, error) {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+
+	if _, ok := g.nodes[key]; !ok {
+		return nil, ErrorNodeNotFound
+	}
+	return g.sortedNeighbors(key), nil
+}
+
+// HasEdge returns true if there is an edge between two nodes
+func (g *Graph) HasEdge(from, to string) bool {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+
+	return g.edges[from][to]
+}
+
 // Nodes returns a list of all nodes in the graph
 func (g *Graph) Nodes() []string {
 	g.lock.RLock()
@@ -117,6 +166,43 @@ func (g *Graph) Nodes() []string {
 	return nodes
 }
 
+// sortedNodes returns all node keys in lexical order
+func (g *Graph) sortedNodes() []string {
+	keys := make([]string, 0, len(g.nodes))
+	for key := range g.nodes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// sortedNeighbors returns the keys of all nodes connected to a node in lexical
+// order
+func (g *Graph) sortedNeighbors(from string) []string {
+	keys := make([]string, 0, len(g.edges[from]))
+	for to, active := range g.edges[from] {
+		if active {
+			keys = append(keys, to)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// sortedEdges returns the keys of all nodes connected to a node in lexical
+// order, leaving out nodes with keys lexically smaller than the node's key, so
+// that every edge is returned only once when iterating over all nodes
+func (g *Graph) sortedEdges(from string) []string {
+	keys := make([]string, 0, len(g.edges[from]))
+	for to, active := range g.edges[from] {
+		if active && from <= to {
+			keys = append(keys, to)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 // String returns a human readable multi-line string describing the graph
 func (g *Graph) String() string {
 	var out bytes.Buffer
@@ -201,6 +201,41 @@ func TestGraphEdges(t *testing.T) {
 	})
 }
 
+func TestGraphNeighbors(t *testing.T) {
+	g := ladder()
+	got, err := g.Neighbors("b")
+	if err != nil {
+		t.Errorf("expected `%v` got `%v`", nil, err)
+	}
+	expected := []string{"a", "d", "e"}
+	if !equal(expected, got) {
+		t.Errorf("expected `%v` got `%v`", expected, got)
+	}
+	if _, err := g.Neighbors("x"); err != ErrorNodeNotFound {
+		t.Errorf("expected `%v` got `%v`", ErrorNodeNotFound, err)
+	}
+}
+
+func TestGraphHasEdge(t *testing.T) {
+	g := ladder()
+	tt := []struct {
+		from, to string
+		expected bool
+	}{
+		{from: "a", to: "b", expected: true},
+		{from: "b", to: "a", expected: true},
+		{from: "a", to: "d", expected: false},
+		{from: "a", to: "x", expected: false},
+		{from: "x", to: "a", expected: false},
+	}
+	for _, tc := range tt {
+		if got := g.HasEdge(tc.from, tc.to); got != tc.expected {
+			t.Errorf("`%v`-`%v`: expected `%v` got `%v`", tc.from, tc.to,
+				tc.expected, got)
+		}
+	}
+}
+
 func TestGraphNodes(t *testing.T) {
 	t.Run("empty graph", func(t *testing.T) {
 		g := New()
@@ -254,3 +289,16 @@ func TestGraphString(t *testing.T) {
 		}
 	})
 }
+
+// test helper equal()
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,24 @@
+package graph
+
+import (
+	"github.com/danrl/golibby/graphalg"
+)
+
+// Isomorphic returns a mapping from the nodes of the graph to the nodes of
+// another graph that preserves edges. If match is not nil, nodes are only
+// mapped to nodes with matching values. graphalg.ErrorNotIsomorphic is
+// returned if there is no such mapping. See graphalg.Isomorphic.
+func (g *Graph) Isomorphic(other *Graph, match graphalg.NodeMatch) (map[string]string, error) {
+	// each graph is indexed from a copy taken under its own lock
+	return graphalg.Isomorphic(g.Freeze(), other.Freeze(), match)
+}
+
+// SubgraphMatches calls yield for every mapping from the nodes of a pattern to
+// nodes of the graph under which the pattern is isomorphic to the subgraph
+// induced by the mapped nodes. The enumeration stops early if yield returns
+// false. Both graphs are copied first, so yield may use them. See
+// graphalg.SubgraphMatches.
+func (g *Graph) SubgraphMatches(pattern *Graph, match graphalg.NodeMatch,
+	yield func(map[string]string) bool) {
+	graphalg.SubgraphMatches(pattern.Freeze(), g.Freeze(), match, yield)
+}
@@ -0,0 +1,58 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/danrl/golibby/graphalg"
+)
+
+func TestGraphIsomorphic(t *testing.T) {
+	a := New()
+	b := New()
+	for _, key := range []string{"a", "b", "c"} {
+		a.NewNode(key, "pipeline")
+		b.NewNode("x"+key, "pipeline")
+	}
+	a.NewNode("d", "sink")
+	b.NewNode("xd", "sink")
+	a.NewEdge("a", "b")
+	a.NewEdge("b", "c")
+	a.NewEdge("c", "d")
+	b.NewEdge("xd", "xa")
+	b.NewEdge("xa", "xb")
+	b.NewEdge("xb", "xc")
+	same := func(a, b interface{}) bool { return a == b }
+	got, err := a.Isomorphic(b, same)
+	if err != nil {
+		t.Errorf("expected `%v` got `%v`", nil, err)
+	}
+	expected := map[string]string{"a": "xc", "b": "xb", "c": "xa", "d": "xd"}
+	for from, to := range expected {
+		if got[from] != to {
+			t.Errorf("expected `%v` got `%v`", expected, got)
+		}
+	}
+	b.NewEdge("xa", "xc")
+	if _, err := a.Isomorphic(b, nil); err != graphalg.ErrorNotIsomorphic {
+		t.Errorf("expected `%v` got `%v`", graphalg.ErrorNotIsomorphic, err)
+	}
+}
+
+func TestGraphSubgraphMatches(t *testing.T) {
+	pattern := New()
+	pattern.NewNode("x", nil)
+	pattern.NewNode("y", nil)
+	pattern.NewEdge("x", "y")
+	var matches []map[string]string
+	ladder().SubgraphMatches(pattern, nil, func(m map[string]string) bool {
+		matches = append(matches, m)
+		return true
+	})
+	// every edge of the ladder matches in both directions
+	if len(matches) != 12 {
+		t.Errorf("expected `%v` got `%v`", 12, len(matches))
+	}
+	if matches[0]["x"] != "a" || matches[0]["y"] != "b" {
+		t.Errorf("expected `%v` got `%v`", map[string]string{"x": "a", "y": "b"}, matches[0])
+	}
+}
@@ -0,0 +1,97 @@
+package graph
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// nodeLink is the node-link JSON representation of a graph
+type nodeLink struct {
+	Directed bool           `json:"directed"`
+	Nodes    []nodeLinkNode `json:"nodes"`
+	Links    []nodeLinkLink `json:"links"`
+}
+
+type nodeLinkNode struct {
+	ID    string      `json:"id"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+type nodeLinkLink struct {
+	Source string   `json:"source"`
+	Target string   `json:"target"`
+	Weight *float64 `json:"weight,omitempty"`
+}
+
+// MarshalJSON encodes the graph in node-link format. Nodes and links are
+// written in lexical order, every edge is written as a single link. Links carry
+// a weight if one has been assigned explicitly.
+func (g *Graph) MarshalJSON() ([]byte, error) {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+
+	nl := nodeLink{
+		Directed: false,
+		Nodes:    []nodeLinkNode{},
+		Links:    []nodeLinkLink{},
+	}
+	keys := g.sortedNodes()
+	for _, key := range keys {
+		nl.Nodes = append(nl.Nodes, nodeLinkNode{
+			ID:    key,
+			Value: g.nodes[key],
+		})
+	}
+	for _, from := range keys {
+		for _, to := range g.sortedEdges(from) {
+			link := nodeLinkLink{
+				Source: from,
+				Target: to,
+			}
+			if weight, ok := g.weights[from][to]; ok {
+				link.Weight = &weight
+			}
+			nl.Links = append(nl.Links, link)
+		}
+	}
+	return json.Marshal(nl)
+}
+
+// UnmarshalJSON decodes a graph in node-link format and replaces all nodes and
+// edges of the graph. Node values are decoded as by encoding/json into an empty
+// interface, e.g. numbers become float64.
+func (g *Graph) UnmarshalJSON(data []byte) error {
+	var nl nodeLink
+	if err := json.Unmarshal(data, &nl); err != nil {
+		return fmt.Errorf("%w: %v", ErrorInvalidFormat, err)
+	}
+	if nl.Directed {
+		return fmt.Errorf("%w: graph is directed", ErrorInvalidFormat)
+	}
+	n := New()
+	for _, nd := range nl.Nodes {
+		if err := n.NewNode(nd.ID, nd.Value); err != nil {
+			return err
+		}
+	}
+	for _, l := range nl.Links {
+		var err error
+		if l.Weight != nil {
+			err = n.NewEdgeWithWeight(l.Source, l.Target, *l.Weight)
+		} else {
+			err = n.NewEdge(l.Source, l.Target)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	g.nodes = n.nodes
+	g.edges = n.edges
+	g.weights = n.weights
+	g.components = n.components
+	return nil
+}
@@ -0,0 +1,118 @@
+package graph
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestGraphMarshalJSON(t *testing.T) {
+	t.Run("empty graph", func(t *testing.T) {
+		got, err := json.Marshal(New())
+		if err != nil {
+			t.Errorf("expected `%v` got `%v`", nil, err)
+		}
+		expected := `{"directed":false,"nodes":[],"links":[]}`
+		if string(got) != expected {
+			t.Errorf("expected `%s` got `%s`", expected, got)
+		}
+	})
+	t.Run("regular graph", func(t *testing.T) {
+		g := New()
+		g.NewNode("a", "foo")
+		g.NewNode("b", nil)
+		g.NewEdge("b", "a")
+		got, err := json.Marshal(g)
+		if err != nil {
+			t.Errorf("expected `%v` got `%v`", nil, err)
+		}
+		expected := `{"directed":false,"nodes":[{"id":"a","value":"foo"},` +
+			`{"id":"b"}],"links":[{"source":"a","target":"b"}]}`
+		if string(got) != expected {
+			t.Errorf("expected `%s` got `%s`", expected, got)
+		}
+	})
+}
+
+func TestGraphUnmarshalJSON(t *testing.T) {
+	t.Run("round trip", func(t *testing.T) {
+		g := New()
+		for _, nd := range nodes {
+			g.NewNode(nd.key, nd.value)
+		}
+		for _, e := range edges {
+			g.NewEdge(e.from, e.to)
+		}
+		data, _ := json.Marshal(g)
+		r := New()
+		r.NewNode("stale", nil)
+		if err := json.Unmarshal(data, r); err != nil {
+			t.Fatalf("expected `%v` got `%v`", nil, err)
+		}
+		if len(r.nodes) != len(nodes) {
+			t.Errorf("expected `%v` nodes, got `%v`", len(nodes), len(r.nodes))
+		}
+		if value := r.nodes["eleven"]; value != float64(11) {
+			t.Errorf("expected node value `%v`, got `%v`", 11, value)
+		}
+		for _, e := range edges {
+			if !r.edges[e.from][e.to] || !r.edges[e.to][e.from] {
+				t.Errorf("expected edge `%v`-`%v` not found.", e.from, e.to)
+			}
+		}
+	})
+	t.Run("weights", func(t *testing.T) {
+		g := New()
+		g.NewNode("a", nil)
+		g.NewNode("b", nil)
+		g.NewNode("c", nil)
+		g.NewEdgeWithWeight("b", "a", 2.5)
+		g.NewEdge("b", "c")
+		data, _ := json.Marshal(g)
+		expected := `{"directed":false,"nodes":[{"id":"a"},{"id":"b"},{"id":"c"}],` +
+			`"links":[{"source":"a","target":"b","weight":2.5},` +
+			`{"source":"b","target":"c"}]}`
+		if got := string(data); got != expected {
+			t.Errorf("expected `%v` got `%v`", expected, got)
+		}
+		r := New()
+		if err := json.Unmarshal(data, r); err != nil {
+			t.Fatalf("expected `%v` got `%v`", nil, err)
+		}
+		if got, _ := r.Weight("b", "a"); got != 2.5 {
+			t.Errorf("expected `%v` got `%v`", 2.5, got)
+		}
+		if _, ok := r.weights["b"]["c"]; ok {
+			t.Errorf("unexpected weight for edge `%v`-`%v`", "b", "c")
+		}
+	})
+	t.Run("zero value graph", func(t *testing.T) {
+		var g Graph
+		err := json.Unmarshal([]byte(`{"nodes":[{"id":"a"}]}`), &g)
+		if err != nil {
+			t.Fatalf("expected `%v` got `%v`", nil, err)
+		}
+		if err := g.NewEdge("a", "a"); err != nil {
+			t.Errorf("expected `%v` got `%v`", nil, err)
+		}
+	})
+	t.Run("invalid input", func(t *testing.T) {
+		tt := []struct {
+			in       string
+			expected error
+		}{
+			{in: `{"nodes":1}`, expected: ErrorInvalidFormat},
+			{in: `{"directed":true}`, expected: ErrorInvalidFormat},
+			{in: `{"nodes":[{"id":"a"},{"id":"a"}]}`,
+				expected: ErrorNodeAlreadyExists},
+			{in: `{"links":[{"source":"a","target":"b"}]}`,
+				expected: ErrorNodeNotFound},
+		}
+		for _, tc := range tt {
+			err := json.Unmarshal([]byte(tc.in), New())
+			if !errors.Is(err, tc.expected) {
+				t.Errorf("input `%v`: expected `%v` got `%v`", tc.in, tc.expected, err)
+			}
+		}
+	})
+}
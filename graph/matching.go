@@ -0,0 +1,392 @@
+package graph
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+var (
+	// ErrorInvalidPartition is returned when the sides of a bipartite problem
+	// share nodes or list a node twice
+	ErrorInvalidPartition = fmt.Errorf("invalid partition")
+	// ErrorNoAssignment is returned when not every node on the left side can be
+	// assigned to a distinct node on the right side
+	ErrorNoAssignment = fmt.Errorf("no assignment")
+)
+
+// sortPairs orders pairs by their first and then by their second key
+func sortPairs(pairs [][2]string) {
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i][0] != pairs[j][0] {
+			return pairs[i][0] < pairs[j][0]
+		}
+		return pairs[i][1] < pairs[j][1]
+	})
+}
+
+// bipartite validates the sides of a bipartite problem without acquiring the
+// lock. It returns, for every node on the left side, the indices of its
+// neighbors on the right side. Edges that do not connect both sides are
+// ignored.
+func (g *Graph) bipartite(left, right []string) ([][]int, error) {
+	side := make(map[string]int, len(left)+len(right))
+	index := make(map[string]int, len(right))
+	for i, keys := range [][]string{left, right} {
+		for j, key := range keys {
+			if _, ok := g.nodes[key]; !ok {
+				return nil, ErrorNodeNotFound
+			}
+			if _, ok := side[key]; ok {
+				return nil, ErrorInvalidPartition
+			}
+			side[key] = i
+			index[key] = j
+		}
+	}
+	adj := make([][]int, len(left))
+	for i, from := range left {
+		for _, to := range g.sortedNeighbors(from) {
+			if s, ok := side[to]; ok && s == 1 {
+				adj[i] = append(adj[i], index[to])
+			}
+		}
+	}
+	return adj, nil
+}
+
+// MaximumBipartiteMatching returns a maximum set of edges between the left and
+// the right side that do not share any node, using the Hopcroft–Karp
+// algorithm. Every pair lists the left node first, pairs are ordered by their
+// keys. Edges that do not connect both sides are ignored. ErrorInvalidPartition
+// is returned if the sides overlap.
+func (g *Graph) MaximumBipartiteMatching(left, right []string) ([][2]string, error) {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+
+	adj, err := g.bipartite(left, right)
+	if err != nil {
+		return nil, err
+	}
+
+	const free = -1
+	matchLeft := make([]int, len(left))
+	matchRight := make([]int, len(right))
+	for i := range matchLeft {
+		matchLeft[i] = free
+	}
+	for i := range matchRight {
+		matchRight[i] = free
+	}
+	dist := make([]int, len(left))
+
+	// bfs layers the left nodes by their distance from a free left node along
+	// alternating paths and reports whether a free right node is reachable
+	bfs := func() bool {
+		var queue []int
+		for u := range left {
+			if matchLeft[u] == free {
+				dist[u] = 0
+				queue = append(queue, u)
+			} else {
+				dist[u] = math.MaxInt32
+			}
+		}
+		found := false
+		for len(queue) > 0 {
+			u := queue[0]
+			queue = queue[1:]
+			for _, v := range adj[u] {
+				w := matchRight[v]
+				if w == free {
+					found = true
+				} else if dist[w] == math.MaxInt32 {
+					dist[w] = dist[u] + 1
+					queue = append(queue, w)
+				}
+			}
+		}
+		return found
+	}
+	// dfs augments along a shortest alternating path starting at u
+	var dfs func(u int) bool
+	dfs = func(u int) bool {
+		for _, v := range adj[u] {
+			w := matchRight[v]
+			if w == free || dist[w] == dist[u]+1 && dfs(w) {
+				matchLeft[u] = v
+				matchRight[v] = u
+				return true
+			}
+		}
+		// no augmenting path through u in this phase
+		dist[u] = math.MaxInt32
+		return false
+	}
+	for bfs() {
+		for u := range left {
+			if matchLeft[u] == free {
+				dfs(u)
+			}
+		}
+	}
+
+	var pairs [][2]string
+	for u, v := range matchLeft {
+		if v != free {
+			pairs = append(pairs, [2]string{left[u], right[v]})
+		}
+	}
+	sortPairs(pairs)
+	return pairs, nil
+}
+
+// MaximumMatching returns a maximum set of edges that do not share any node,
+// using Edmonds' blossom algorithm. Every pair lists the lexically smaller key
+// first, pairs are ordered by their keys. Self-loops are ignored.
+func (g *Graph) MaximumMatching() [][2]string {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+
+	keys := g.sortedNodes()
+	n := len(keys)
+	index := make(map[string]int, n)
+	for i, key := range keys {
+		index[key] = i
+	}
+	adj := make([][]int, n)
+	for i, key := range keys {
+		for _, to := range g.adjacent(key) {
+			adj[i] = append(adj[i], index[to])
+		}
+	}
+
+	const none = -1
+	match := make([]int, n)
+	parent := make([]int, n)
+	base := make([]int, n)
+	used := make([]bool, n)
+	blossom := make([]bool, n)
+	for i := range match {
+		match[i] = none
+	}
+
+	// lca returns the base of the blossom closed by an edge between a and b
+	lca := func(a, b int) int {
+		seen := make([]bool, n)
+		for {
+			a = base[a]
+			seen[a] = true
+			if match[a] == none {
+				break
+			}
+			a = parent[match[a]]
+		}
+		for {
+			b = base[b]
+			if seen[b] {
+				return b
+			}
+			b = parent[match[b]]
+		}
+	}
+	// markPath marks the blossom bases on the path from v to the blossom base b
+	markPath := func(v, b, child int) {
+		for base[v] != b {
+			blossom[base[v]] = true
+			blossom[base[match[v]]] = true
+			parent[v] = child
+			child = match[v]
+			v = parent[match[v]]
+		}
+	}
+	// findPath searches an augmenting path from the free node root and returns
+	// its free end, or none
+	findPath := func(root int) int {
+		for i := 0; i < n; i++ {
+			used[i] = false
+			parent[i] = none
+			base[i] = i
+		}
+		used[root] = true
+		queue := []int{root}
+		for len(queue) > 0 {
+			v := queue[0]
+			queue = queue[1:]
+			for _, to := range adj[v] {
+				if base[v] == base[to] || match[v] == to {
+					continue
+				}
+				if to == root || match[to] != none && parent[match[to]] != none {
+					// odd cycle, contract the blossom
+					b := lca(v, to)
+					for i := range blossom {
+						blossom[i] = false
+					}
+					markPath(v, b, to)
+					markPath(to, b, v)
+					for i := 0; i < n; i++ {
+						if blossom[base[i]] {
+							base[i] = b
+							if !used[i] {
+								used[i] = true
+								queue = append(queue, i)
+							}
+						}
+					}
+				} else if parent[to] == none {
+					parent[to] = v
+					if match[to] == none {
+						return to
+					}
+					used[match[to]] = true
+					queue = append(queue, match[to])
+				}
+			}
+		}
+		return none
+	}
+
+	for root := 0; root < n; root++ {
+		if match[root] != none {
+			continue
+		}
+		// augment along the path by flipping matched and unmatched edges
+		for v := findPath(root); v != none; {
+			pv := parent[v]
+			next := match[pv]
+			match[v] = pv
+			match[pv] = v
+			v = next
+		}
+	}
+
+	var pairs [][2]string
+	for i, j := range match {
+		if j != none && i < j {
+			pairs = append(pairs, [2]string{keys[i], keys[j]})
+		}
+	}
+	return pairs
+}
+
+// MinimumWeightAssignment assigns every node on the left side to a distinct
+// neighbor on the right side so that the total weight of the used edges is
+// minimal, using the Hungarian algorithm. It returns the assigned pairs with
+// the left node first, ordered by their keys, and the total weight.
+// ErrorNoAssignment is returned if no such assignment exists,
+// ErrorInvalidPartition if the sides overlap and ErrorInvalidWeight if the
+// weights are too large to be summed up.
+func (g *Graph) MinimumWeightAssignment(left, right []string) ([][2]string, float64, error) {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+
+	adj, err := g.bipartite(left, right)
+	if err != nil {
+		return nil, 0, err
+	}
+	n, m := len(left), len(right)
+	if n > m {
+		return nil, 0, ErrorNoAssignment
+	}
+	if n == 0 {
+		return nil, 0, nil
+	}
+
+	// missing edges cost more than any assignment that only uses edges
+	sum := 0.0
+	for u, vs := range adj {
+		for _, v := range vs {
+			sum += math.Abs(g.weight(left[u], right[v]))
+		}
+	}
+	missing := 2*sum + 1
+	if math.IsInf(missing, 0) {
+		return nil, 0, ErrorInvalidWeight
+	}
+	cost := make([][]float64, n)
+	exists := make([][]bool, n)
+	for u := range cost {
+		cost[u] = make([]float64, m)
+		exists[u] = make([]bool, m)
+		for v := range cost[u] {
+			cost[u][v] = missing
+		}
+		for _, v := range adj[u] {
+			cost[u][v] = g.weight(left[u], right[v])
+			exists[u][v] = true
+		}
+	}
+
+	// shortest augmenting path formulation with potentials u and v on the rows
+	// and columns, indices are shifted by one so that column 0 is a sentinel
+	pu := make([]float64, n+1)
+	pv := make([]float64, m+1)
+	row := make([]int, m+1)
+	way := make([]int, m+1)
+	for i := 1; i <= n; i++ {
+		row[0] = i
+		j0 := 0
+		minv := make([]float64, m+1)
+		used := make([]bool, m+1)
+		for j := range minv {
+			minv[j] = math.Inf(1)
+		}
+		for {
+			used[j0] = true
+			i0 := row[j0]
+			delta := math.Inf(1)
+			j1 := 0
+			for j := 1; j <= m; j++ {
+				if used[j] {
+					continue
+				}
+				if c := cost[i0-1][j-1] - pu[i0] - pv[j]; c < minv[j] {
+					minv[j] = c
+					way[j] = j0
+				}
+				if minv[j] < delta {
+					delta = minv[j]
+					j1 = j
+				}
+			}
+			for j := 0; j <= m; j++ {
+				if used[j] {
+					pu[row[j]] += delta
+					pv[j] -= delta
+				} else {
+					minv[j] -= delta
+				}
+			}
+			if j1 == 0 {
+				// reduced costs overflowed, no column can be reached
+				return nil, 0, ErrorInvalidWeight
+			}
+			j0 = j1
+			if row[j0] == 0 {
+				break
+			}
+		}
+		for j0 != 0 {
+			j1 := way[j0]
+			row[j0] = row[j1]
+			j0 = j1
+		}
+	}
+
+	var pairs [][2]string
+	total := 0.0
+	for j := 1; j <= m; j++ {
+		if row[j] == 0 {
+			continue
+		}
+		u, v := row[j]-1, j-1
+		if !exists[u][v] {
+			return nil, 0, ErrorNoAssignment
+		}
+		pairs = append(pairs, [2]string{left[u], right[v]})
+		total += cost[u][v]
+	}
+	sortPairs(pairs)
+	return pairs, total, nil
+}
@@ -0,0 +1,244 @@
+package graph
+
+import (
+	"math"
+	"strconv"
+	"testing"
+)
+
+// validMatching reports whether all pairs are edges of the graph and no node is
+// part of more than one pair
+func validMatching(g *Graph, pairs [][2]string) bool {
+	seen := make(map[string]bool)
+	for _, p := range pairs {
+		if !g.edges[p[0]][p[1]] || p[0] == p[1] || seen[p[0]] || seen[p[1]] {
+			return false
+		}
+		seen[p[0]] = true
+		seen[p[1]] = true
+	}
+	return true
+}
+
+// bruteForceMatching returns the size of a maximum matching by trying all
+// subsets of edges
+func bruteForceMatching(g *Graph) int {
+	var edges [][2]string
+	for _, from := range g.sortedNodes() {
+		for _, to := range g.sortedEdges(from) {
+			if from != to {
+				edges = append(edges, [2]string{from, to})
+			}
+		}
+	}
+	var best func(i int, used map[string]bool) int
+	best = func(i int, used map[string]bool) int {
+		if i == len(edges) {
+			return 0
+		}
+		size := best(i+1, used)
+		e := edges[i]
+		if !used[e[0]] && !used[e[1]] {
+			used[e[0]], used[e[1]] = true, true
+			if s := 1 + best(i+1, used); s > size {
+				size = s
+			}
+			used[e[0]], used[e[1]] = false, false
+		}
+		return size
+	}
+	return best(0, make(map[string]bool))
+}
+
+func TestGraphMaximumBipartiteMatching(t *testing.T) {
+	t.Run("volunteers and shifts", func(t *testing.T) {
+		g := New()
+		left := []string{"v1", "v2", "v3", "v4"}
+		right := []string{"s1", "s2", "s3", "s4"}
+		for _, key := range append(left, right...) {
+			g.NewNode(key, nil)
+		}
+		for _, e := range [][2]string{{"v1", "s1"}, {"v1", "s2"}, {"v2", "s1"},
+			{"v3", "s2"}, {"v3", "s3"}, {"v4", "s3"}, {"v1", "v2"}} {
+			g.NewEdge(e[0], e[1])
+		}
+		pairs, err := g.MaximumBipartiteMatching(left, right)
+		if err != nil {
+			t.Errorf("expected `%v` got `%v`", nil, err)
+		}
+		if !validMatching(g, pairs) {
+			t.Errorf("matching `%v` is not valid", pairs)
+		}
+		if len(pairs) != 3 {
+			t.Errorf("expected `%v` pairs got `%v`", 3, pairs)
+		}
+		for _, p := range pairs {
+			if p[0][0] != 'v' {
+				t.Errorf("expected left node first in pair `%v`", p)
+			}
+		}
+	})
+	t.Run("complete bipartite graph", func(t *testing.T) {
+		g := New()
+		var left, right []string
+		for i := 0; i < 50; i++ {
+			left = append(left, "l"+strconv.Itoa(i))
+			right = append(right, "r"+strconv.Itoa(i))
+			g.NewNode(left[i], nil)
+			g.NewNode(right[i], nil)
+		}
+		for i := range left {
+			for j := range right {
+				if i <= j {
+					g.NewEdge(left[i], right[j])
+				}
+			}
+		}
+		pairs, _ := g.MaximumBipartiteMatching(left, right)
+		if len(pairs) != 50 || !validMatching(g, pairs) {
+			t.Errorf("expected perfect matching got `%v`", pairs)
+		}
+	})
+	t.Run("invalid partition", func(t *testing.T) {
+		g := New()
+		g.NewNode("a", nil)
+		g.NewNode("b", nil)
+		if _, err := g.MaximumBipartiteMatching([]string{"a"},
+			[]string{"a", "b"}); err != ErrorInvalidPartition {
+			t.Errorf("expected `%v` got `%v`", ErrorInvalidPartition, err)
+		}
+		if _, err := g.MaximumBipartiteMatching([]string{"a"},
+			[]string{"x"}); err != ErrorNodeNotFound {
+			t.Errorf("expected `%v` got `%v`", ErrorNodeNotFound, err)
+		}
+	})
+}
+
+func TestGraphMaximumMatching(t *testing.T) {
+	t.Run("empty graph", func(t *testing.T) {
+		if got := New().MaximumMatching(); len(got) != 0 {
+			t.Errorf("expected `%v` got `%v`", nil, got)
+		}
+	})
+	t.Run("blossom", func(t *testing.T) {
+		// the augmenting path f-e-...-a-g leads through an odd cycle
+		g := New()
+		for _, key := range []string{"a", "b", "c", "d", "e", "f", "g"} {
+			g.NewNode(key, nil)
+		}
+		for _, e := range [][2]string{{"a", "b"}, {"b", "c"}, {"c", "d"},
+			{"d", "e"}, {"e", "a"}, {"e", "f"}, {"a", "g"}} {
+			g.NewEdge(e[0], e[1])
+		}
+		got := g.MaximumMatching()
+		if len(got) != 3 || !validMatching(g, got) {
+			t.Errorf("expected maximum matching of size `%v` got `%v`", 3, got)
+		}
+	})
+	t.Run("exhaustive check", func(t *testing.T) {
+		keys := []string{"a", "b", "c", "d", "e", "f", "g", "h", "i"}
+		for seed := 1; seed <= 30; seed++ {
+			g := New()
+			for _, key := range keys {
+				g.NewNode(key, nil)
+			}
+			for i := range keys {
+				for j := i; j < len(keys); j++ {
+					if (seed*(i+2)*(j+3)+i)%11 < 3 {
+						g.NewEdge(keys[i], keys[j])
+					}
+				}
+			}
+			got := g.MaximumMatching()
+			if !validMatching(g, got) {
+				t.Fatalf("matching `%v` is not valid", got)
+			}
+			if expected := bruteForceMatching(g); len(got) != expected {
+				t.Fatalf("expected matching of size `%v` got `%v`", expected, got)
+			}
+		}
+	})
+}
+
+func TestGraphMinimumWeightAssignment(t *testing.T) {
+	// workers w0-w2 and jobs j0-j3 with the costs
+	//
+	//	    j0 j1 j2 j3
+	//	w0   4  1  3  -
+	//	w1   2  0  5  -
+	//	w2   3  2  2  9
+	costs := func() *Graph {
+		g := New()
+		for _, key := range []string{"w0", "w1", "w2", "j0", "j1", "j2", "j3"} {
+			g.NewNode(key, nil)
+		}
+		for w, row := range [][]float64{{4, 1, 3}, {2, 0, 5}, {3, 2, 2, 9}} {
+			for j, cost := range row {
+				g.NewEdgeWithWeight("w"+strconv.Itoa(w), "j"+strconv.Itoa(j), cost)
+			}
+		}
+		return g
+	}
+	workers := []string{"w0", "w1", "w2"}
+	t.Run("square", func(t *testing.T) {
+		pairs, total, err := costs().MinimumWeightAssignment(workers,
+			[]string{"j0", "j1", "j2"})
+		if err != nil {
+			t.Errorf("expected `%v` got `%v`", nil, err)
+		}
+		expected := [][2]string{{"w0", "j1"}, {"w1", "j0"}, {"w2", "j2"}}
+		if len(pairs) != len(expected) {
+			t.Fatalf("expected `%v` got `%v`", expected, pairs)
+		}
+		for i := range expected {
+			if pairs[i] != expected[i] {
+				t.Errorf("expected `%v` got `%v`", expected[i], pairs[i])
+			}
+		}
+		if total != 5 {
+			t.Errorf("expected `%v` got `%v`", 5, total)
+		}
+	})
+	t.Run("more jobs than workers", func(t *testing.T) {
+		g := costs()
+		g.SetWeight("w2", "j3", -1)
+		_, total, err := g.MinimumWeightAssignment(workers,
+			[]string{"j0", "j1", "j2", "j3"})
+		if err != nil {
+			t.Errorf("expected `%v` got `%v`", nil, err)
+		}
+		if total != 2 {
+			t.Errorf("expected `%v` got `%v`", 2, total)
+		}
+	})
+	t.Run("no assignment", func(t *testing.T) {
+		g := costs()
+		if _, _, err := g.MinimumWeightAssignment(workers,
+			[]string{"j0", "j3"}); err != ErrorNoAssignment {
+			t.Errorf("expected `%v` got `%v`", ErrorNoAssignment, err)
+		}
+		g.NewNode("w3", nil)
+		g.NewEdge("w3", "j3")
+		if _, _, err := g.MinimumWeightAssignment([]string{"w0", "w1", "w2", "w3"},
+			[]string{"j0", "j1", "j2", "j3"}); err != nil {
+			t.Errorf("expected `%v` got `%v`", nil, err)
+		}
+		if _, _, err := g.MinimumWeightAssignment([]string{"w0", "w1"},
+			[]string{"j3"}); err != ErrorNoAssignment {
+			t.Errorf("expected `%v` got `%v`", ErrorNoAssignment, err)
+		}
+	})
+	t.Run("overflowing weights", func(t *testing.T) {
+		g := New()
+		for _, key := range []string{"a", "b", "x", "y"} {
+			g.NewNode(key, nil)
+		}
+		g.NewEdgeWithWeight("a", "x", math.MaxFloat64)
+		g.NewEdgeWithWeight("a", "y", math.MaxFloat64)
+		g.NewEdgeWithWeight("b", "x", 1)
+		if _, _, err := g.MinimumWeightAssignment([]string{"a", "b"},
+			[]string{"x", "y"}); err != ErrorInvalidWeight {
+			t.Errorf("expected `%v` got `%v`", ErrorInvalidWeight, err)
+		}
+	})
+}
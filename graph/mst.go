@@ -0,0 +1,138 @@
+package graph
+
+import (
+	"sort"
+
+	"github.com/danrl/golibby/heap"
+	"github.com/danrl/golibby/unionfind"
+)
+
+// Edge is a weighted edge between two nodes. From is the lexically smaller key.
+type Edge struct {
+	From, To string
+	Weight   float64
+}
+
+// Tree is a minimum spanning tree of a connected component. Nodes lists the
+// keys of the component in lexical order, Edges lists the edges of the tree
+// ordered by their keys, and Weight is the total weight of the edges.
+type Tree struct {
+	Nodes  []string
+	Edges  []Edge
+	Weight float64
+}
+
+// newEdge returns an edge with its keys in lexical order
+func newEdge(a, b string, weight float64) Edge {
+	if b < a {
+		a, b = b, a
+	}
+	return Edge{From: a, To: b, Weight: weight}
+}
+
+// sortEdges orders edges by their keys
+func sortEdges(edges []Edge) {
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].From != edges[j].From {
+			return edges[i].From < edges[j].From
+		}
+		return edges[i].To < edges[j].To
+	})
+}
+
+// forest returns one tree per connected component with the edges of each tree
+// taken from the given edges
+func (g *Graph) forest(edges []Edge) []Tree {
+	components := g.connectedComponents()
+	index := make(map[string]int)
+	forest := make([]Tree, len(components))
+	for i, nodes := range components {
+		forest[i].Nodes = nodes
+		for _, key := range nodes {
+			index[key] = i
+		}
+	}
+	sortEdges(edges)
+	for _, e := range edges {
+		tree := &forest[index[e.From]]
+		tree.Edges = append(tree.Edges, e)
+		tree.Weight += e.Weight
+	}
+	return forest
+}
+
+// MinimumSpanningForest returns a minimum spanning tree for every connected
+// component of the graph using Kruskal's algorithm. Trees are ordered by the
+// lexically smallest key of their component. Nodes without edges are spanned
+// by a tree without edges. Self-loops are never part of a tree.
+func (g *Graph) MinimumSpanningForest() []Tree {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+
+	var candidates []Edge
+	for _, from := range g.sortedNodes() {
+		for _, to := range g.sortedEdges(from) {
+			if from != to {
+				candidates = append(candidates, Edge{
+					From:   from,
+					To:     to,
+					Weight: g.weight(from, to),
+				})
+			}
+		}
+	}
+	// stable sort keeps ties in key order, which makes the result deterministic
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Weight < candidates[j].Weight
+	})
+
+	uf := unionfind.UnionFind{}
+	for key := range g.nodes {
+		uf.Add(key)
+	}
+	var edges []Edge
+	for _, e := range candidates {
+		if merged, _ := uf.Union(e.From, e.To); merged {
+			edges = append(edges, e)
+		}
+	}
+	return g.forest(edges)
+}
+
+// MinimumSpanningForestPrim returns a minimum spanning tree for every connected
+// component of the graph using Prim's algorithm with a binary heap. Each tree is
+// grown from the lexically smallest key of its component. The result has the
+// same form as the result of MinimumSpanningForest, if edge weights are not
+// unique the trees may differ but their total weights are the same.
+func (g *Graph) MinimumSpanningForestPrim() []Tree {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+
+	visited := make(map[string]bool)
+	var edges []Edge
+	for _, start := range g.sortedNodes() {
+		if visited[start] {
+			continue
+		}
+		visited[start] = true
+		pq := heap.PriorityQueue{}
+		for _, to := range g.sortedNeighbors(start) {
+			pq.Insert([2]string{start, to}, g.weight(start, to))
+		}
+		for pq.Len() > 0 {
+			item, weight, _ := pq.Pop()
+			e := item.([2]string)
+			if visited[e[1]] {
+				continue
+			}
+			visited[e[1]] = true
+			edges = append(edges, newEdge(e[0], e[1], weight))
+			for _, to := range g.sortedNeighbors(e[1]) {
+				if !visited[to] {
+					pq.Insert([2]string{e[1], to}, g.weight(e[1], to))
+				}
+			}
+		}
+	}
+	return g.forest(edges)
+}
@@ -0,0 +1,127 @@
+package graph
+
+import (
+	"testing"
+)
+
+// cabling returns a weighted graph with two components and an isolated node
+//
+//	a -1- b -4- d     x -2- y
+//	 \    |    /      |     |
+//	  3   2   5       2     2
+//	   \  |  /        |     |
+//	      c           z -2- w     q
+func cabling() *Graph {
+	g := New()
+	for _, key := range []string{"a", "b", "c", "d", "q", "w", "x", "y", "z"} {
+		g.NewNode(key, nil)
+	}
+	for _, e := range []Edge{
+		{"a", "b", 1}, {"a", "c", 3}, {"b", "c", 2}, {"b", "d", 4},
+		{"c", "d", 5}, {"x", "y", 2}, {"x", "z", 2}, {"w", "y", 2},
+		{"w", "z", 2},
+	} {
+		g.NewEdgeWithWeight(e.From, e.To, e.Weight)
+	}
+	g.NewEdge("c", "c")
+	return g
+}
+
+func equalTrees(a, b []Tree) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !equal(a[i].Nodes, b[i].Nodes) || a[i].Weight != b[i].Weight ||
+			len(a[i].Edges) != len(b[i].Edges) {
+			return false
+		}
+		for j := range a[i].Edges {
+			if a[i].Edges[j] != b[i].Edges[j] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func TestGraphMinimumSpanningForest(t *testing.T) {
+	t.Run("empty graph", func(t *testing.T) {
+		if got := New().MinimumSpanningForest(); len(got) != 0 {
+			t.Errorf("expected `%v` got `%v`", nil, got)
+		}
+	})
+	t.Run("weighted graph", func(t *testing.T) {
+		expected := []Tree{
+			{
+				Nodes:  []string{"a", "b", "c", "d"},
+				Edges:  []Edge{{"a", "b", 1}, {"b", "c", 2}, {"b", "d", 4}},
+				Weight: 7,
+			},
+			{Nodes: []string{"q"}},
+			{
+				Nodes:  []string{"w", "x", "y", "z"},
+				Edges:  []Edge{{"w", "y", 2}, {"w", "z", 2}, {"x", "y", 2}},
+				Weight: 6,
+			},
+		}
+		if got := cabling().MinimumSpanningForest(); !equalTrees(expected, got) {
+			t.Errorf("expected `%v` got `%v`", expected, got)
+		}
+	})
+}
+
+func TestGraphMinimumSpanningForestPrim(t *testing.T) {
+	t.Run("empty graph", func(t *testing.T) {
+		if got := New().MinimumSpanningForestPrim(); len(got) != 0 {
+			t.Errorf("expected `%v` got `%v`", nil, got)
+		}
+	})
+	t.Run("weighted graph", func(t *testing.T) {
+		expected := []Tree{
+			{
+				Nodes:  []string{"a", "b", "c", "d"},
+				Edges:  []Edge{{"a", "b", 1}, {"b", "c", 2}, {"b", "d", 4}},
+				Weight: 7,
+			},
+			{Nodes: []string{"q"}},
+			{
+				Nodes:  []string{"w", "x", "y", "z"},
+				Edges:  []Edge{{"w", "y", 2}, {"w", "z", 2}, {"x", "y", 2}},
+				Weight: 6,
+			},
+		}
+		if got := cabling().MinimumSpanningForestPrim(); !equalTrees(expected, got) {
+			t.Errorf("expected `%v` got `%v`", expected, got)
+		}
+	})
+	t.Run("same weight as kruskal", func(t *testing.T) {
+		g := New()
+		for i := 0; i < 20; i++ {
+			g.NewNode(string(rune('a'+i)), nil)
+		}
+		for i := 0; i < 20; i++ {
+			for j := i + 1; j < 20; j++ {
+				if (i*7+j*3)%4 == 0 {
+					g.NewEdgeWithWeight(string(rune('a'+i)), string(rune('a'+j)),
+						float64((i*j)%5))
+				}
+			}
+		}
+		kruskal := g.MinimumSpanningForest()
+		prim := g.MinimumSpanningForestPrim()
+		if len(kruskal) != len(prim) {
+			t.Fatalf("expected `%v` trees got `%v`", len(kruskal), len(prim))
+		}
+		for i := range kruskal {
+			if kruskal[i].Weight != prim[i].Weight {
+				t.Errorf("expected weight `%v` got `%v`", kruskal[i].Weight,
+					prim[i].Weight)
+			}
+			if len(kruskal[i].Edges) != len(prim[i].Edges) {
+				t.Errorf("expected `%v` edges got `%v`", len(kruskal[i].Edges),
+					len(prim[i].Edges))
+			}
+		}
+	})
+}
@@ -0,0 +1,80 @@
+package graph
+
+import (
+	"github.com/danrl/golibby/graphalg"
+)
+
+// ErrorNoPath is returned when there is no path between two nodes
+var ErrorNoPath = graphalg.ErrorNoPath
+
+// view gives graph algorithms access to a graph whose lock is already held by
+// the caller
+type view struct {
+	g *Graph
+}
+
+func (v view) Nodes() []string {
+	return v.g.sortedNodes()
+}
+
+func (v view) Neighbors(key string) ([]string, error) {
+	if _, ok := v.g.nodes[key]; !ok {
+		return nil, ErrorNodeNotFound
+	}
+	return v.g.sortedNeighbors(key), nil
+}
+
+func (v view) HasEdge(from, to string) bool {
+	return v.g.edges[from][to]
+}
+
+func (v view) Value(key string) (interface{}, error) {
+	value, ok := v.g.nodes[key]
+	if !ok {
+		return nil, ErrorNodeNotFound
+	}
+	return value, nil
+}
+
+// BFS traverses the graph breadth first, starting at the node identified by
+// start. The visit function is called once for every reachable node with the
+// node's distance from the start node, neighbors are visited in lexical order.
+// The traversal stops early if visit returns false. The visit function must not
+// modify the graph. See graphalg.BFS.
+func (g *Graph) BFS(start string, visitFn func(key string, depth int) bool) error {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+
+	return graphalg.BFS(view{g: g}, start, visitFn)
+}
+
+// DFS traverses the graph depth first, starting at the node identified by
+// start. The visit function is called once for every reachable node with the
+// node's depth in the depth first search tree, neighbors are visited in
+// lexical order. The traversal stops early if visit returns false. The visit
+// function must not modify the graph. See graphalg.DFS.
+func (g *Graph) DFS(start string, visitFn func(key string, depth int) bool) error {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+
+	return graphalg.DFS(view{g: g}, start, visitFn)
+}
+
+// Distances returns the number of edges on a shortest path from the node
+// identified by from to every reachable node
+func (g *Graph) Distances(from string) (map[string]int, error) {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+
+	return graphalg.Distances(view{g: g}, from)
+}
+
+// ShortestPath returns the keys of the nodes along a path with the least
+// number of edges between two nodes, including both nodes. ErrorNoPath is
+// returned if the nodes are not connected.
+func (g *Graph) ShortestPath(from, to string) ([]string, error) {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+
+	return graphalg.ShortestPath(view{g: g}, from, to)
+}
@@ -0,0 +1,167 @@
+package graph
+
+import (
+	"testing"
+)
+
+// ladder returns a graph shaped like a ladder with two rungs and a tail
+//
+//	a - b - e - f
+//	|   |
+//	c - d
+func ladder() *Graph {
+	g := New()
+	for _, key := range []string{"a", "b", "c", "d", "e", "f", "z"} {
+		g.NewNode(key, nil)
+	}
+	for _, e := range [][2]string{{"a", "b"}, {"a", "c"}, {"b", "d"}, {"c", "d"},
+		{"b", "e"}, {"e", "f"}} {
+		g.NewEdge(e[0], e[1])
+	}
+	return g
+}
+
+func TestGraphBFS(t *testing.T) {
+	t.Run("full traversal", func(t *testing.T) {
+		var keys []string
+		var depths []int
+		err := ladder().BFS("a", func(key string, depth int) bool {
+			keys = append(keys, key)
+			depths = append(depths, depth)
+			return true
+		})
+		if err != nil {
+			t.Errorf("expected `%v` got `%v`", nil, err)
+		}
+		expected := []string{"a", "b", "c", "d", "e", "f"}
+		if !equal(expected, keys) {
+			t.Errorf("expected `%v` got `%v`", expected, keys)
+		}
+		expectedDepths := []int{0, 1, 1, 2, 2, 3}
+		for i := range expectedDepths {
+			if depths[i] != expectedDepths[i] {
+				t.Errorf("node `%v`: expected depth `%v` got `%v`", keys[i],
+					expectedDepths[i], depths[i])
+			}
+		}
+	})
+	t.Run("early stop", func(t *testing.T) {
+		var keys []string
+		ladder().BFS("a", func(key string, depth int) bool {
+			keys = append(keys, key)
+			return len(keys) < 3
+		})
+		if len(keys) != 3 {
+			t.Errorf("expected `%v` visits got `%v`", 3, len(keys))
+		}
+	})
+	t.Run("unknown node", func(t *testing.T) {
+		err := New().BFS("a", func(string, int) bool { return true })
+		if err != ErrorNodeNotFound {
+			t.Errorf("expected `%v` got `%v`", ErrorNodeNotFound, err)
+		}
+	})
+}
+
+func TestGraphDFS(t *testing.T) {
+	t.Run("full traversal", func(t *testing.T) {
+		var keys []string
+		var depths []int
+		err := ladder().DFS("a", func(key string, depth int) bool {
+			keys = append(keys, key)
+			depths = append(depths, depth)
+			return true
+		})
+		if err != nil {
+			t.Errorf("expected `%v` got `%v`", nil, err)
+		}
+		expected := []string{"a", "b", "d", "c", "e", "f"}
+		if !equal(expected, keys) {
+			t.Errorf("expected `%v` got `%v`", expected, keys)
+		}
+		expectedDepths := []int{0, 1, 2, 3, 2, 3}
+		for i := range expectedDepths {
+			if depths[i] != expectedDepths[i] {
+				t.Errorf("node `%v`: expected depth `%v` got `%v`", keys[i],
+					expectedDepths[i], depths[i])
+			}
+		}
+	})
+	t.Run("early stop", func(t *testing.T) {
+		var keys []string
+		ladder().DFS("a", func(key string, depth int) bool {
+			keys = append(keys, key)
+			return key != "d"
+		})
+		expected := []string{"a", "b", "d"}
+		if !equal(expected, keys) {
+			t.Errorf("expected `%v` got `%v`", expected, keys)
+		}
+	})
+	t.Run("unknown node", func(t *testing.T) {
+		err := New().DFS("a", func(string, int) bool { return true })
+		if err != ErrorNodeNotFound {
+			t.Errorf("expected `%v` got `%v`", ErrorNodeNotFound, err)
+		}
+	})
+}
+
+func TestGraphDistances(t *testing.T) {
+	t.Run("existing node", func(t *testing.T) {
+		got, err := ladder().Distances("d")
+		if err != nil {
+			t.Errorf("expected `%v` got `%v`", nil, err)
+		}
+		expected := map[string]int{"a": 2, "b": 1, "c": 1, "d": 0, "e": 2, "f": 3}
+		if len(got) != len(expected) {
+			t.Errorf("expected `%v` distances got `%v`", len(expected), len(got))
+		}
+		for key, distance := range expected {
+			if got[key] != distance {
+				t.Errorf("node `%v`: expected `%v` got `%v`", key, distance, got[key])
+			}
+		}
+	})
+	t.Run("unknown node", func(t *testing.T) {
+		if _, err := New().Distances("a"); err != ErrorNodeNotFound {
+			t.Errorf("expected `%v` got `%v`", ErrorNodeNotFound, err)
+		}
+	})
+}
+
+func TestGraphShortestPath(t *testing.T) {
+	t.Run("connected nodes", func(t *testing.T) {
+		g := ladder()
+		tt := []struct {
+			from, to string
+			expected []string
+		}{
+			{from: "c", to: "f", expected: []string{"c", "a", "b", "e", "f"}},
+			{from: "f", to: "d", expected: []string{"f", "e", "b", "d"}},
+			{from: "a", to: "a", expected: []string{"a"}},
+		}
+		for _, tc := range tt {
+			got, err := g.ShortestPath(tc.from, tc.to)
+			if err != nil {
+				t.Errorf("expected `%v` got `%v`", nil, err)
+			}
+			if !equal(tc.expected, got) {
+				t.Errorf("expected `%v` got `%v`", tc.expected, got)
+			}
+		}
+	})
+	t.Run("disconnected nodes", func(t *testing.T) {
+		if _, err := ladder().ShortestPath("a", "z"); err != ErrorNoPath {
+			t.Errorf("expected `%v` got `%v`", ErrorNoPath, err)
+		}
+	})
+	t.Run("unknown nodes", func(t *testing.T) {
+		g := ladder()
+		if _, err := g.ShortestPath("a", "x"); err != ErrorNodeNotFound {
+			t.Errorf("expected `%v` got `%v`", ErrorNodeNotFound, err)
+		}
+		if _, err := g.ShortestPath("x", "a"); err != ErrorNodeNotFound {
+			t.Errorf("expected `%v` got `%v`", ErrorNodeNotFound, err)
+		}
+	})
+}
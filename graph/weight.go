@@ -0,0 +1,92 @@
+package graph
+
+import "math"
+
+// DefaultWeight is the weight of edges that have not been assigned a weight
+// explicitly
+const DefaultWeight = 1.0
+
+// setWeight stores the weight of an edge in both directions without acquiring
+// the lock
+func (g *Graph) setWeight(from, to string, weight float64) {
+	if g.weights[from] == nil {
+		g.weights[from] = make(map[string]float64)
+	}
+	if g.weights[to] == nil {
+		g.weights[to] = make(map[string]float64)
+	}
+	g.weights[from][to] = weight
+	g.weights[to][from] = weight
+}
+
+// validWeight reports whether a weight is a finite number
+func validWeight(weight float64) bool {
+	return !math.IsNaN(weight) && !math.IsInf(weight, 0)
+}
+
+// weight returns the weight of an existing edge without acquiring the lock
+func (g *Graph) weight(from, to string) float64 {
+	if weight, ok := g.weights[from][to]; ok {
+		return weight
+	}
+	return DefaultWeight
+}
+
+// NewEdgeWithWeight adds an edge between to nodes in the graph and assigns a
+// weight to it. If the edge exists already, only its weight is updated.
+// ErrorInvalidWeight is returned for infinite and NaN weights.
+func (g *Graph) NewEdgeWithWeight(from, to string, weight float64) error {
+	if !validWeight(weight) {
+		return ErrorInvalidWeight
+	}
+
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	if err := g.newEdge(from, to); err != nil {
+		return err
+	}
+	g.setWeight(from, to, weight)
+	return nil
+}
+
+// SetWeight assigns a weight to an existing edge. ErrorInvalidWeight is
+// returned for infinite and NaN weights.
+func (g *Graph) SetWeight(from, to string, weight float64) error {
+	if !validWeight(weight) {
+		return ErrorInvalidWeight
+	}
+
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	if _, ok := g.nodes[from]; !ok {
+		return ErrorNodeNotFound
+	}
+	if _, ok := g.nodes[to]; !ok {
+		return ErrorNodeNotFound
+	}
+	if !g.edges[from][to] {
+		return ErrorEdgeNotFound
+	}
+	g.setWeight(from, to, weight)
+	return nil
+}
+
+// Weight returns the weight of an edge. Edges that have not been assigned a
+// weight have DefaultWeight.
+func (g *Graph) Weight(from, to string) (float64, error) {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+
+	if _, ok := g.nodes[from]; !ok {
+		return 0, ErrorNodeNotFound
+	}
+	if _, ok := g.nodes[to]; !ok {
+		return 0, ErrorNodeNotFound
+	}
+	if !g.edges[from][to] {
+		return 0, ErrorEdgeNotFound
+	}
+	return g.weight(from, to), nil
+}
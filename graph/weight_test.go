@@ -0,0 +1,85 @@
+package graph
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGraphWeight(t *testing.T) {
+	t.Run("default weight", func(t *testing.T) {
+		g := New()
+		g.NewNode("a", nil)
+		g.NewNode("b", nil)
+		g.NewEdge("a", "b")
+		got, err := g.Weight("b", "a")
+		if err != nil {
+			t.Errorf("expected `%v` got `%v`", nil, err)
+		}
+		if got != DefaultWeight {
+			t.Errorf("expected `%v` got `%v`", DefaultWeight, got)
+		}
+	})
+	t.Run("assigned weight", func(t *testing.T) {
+		g := New()
+		g.NewNode("a", nil)
+		g.NewNode("b", nil)
+		if err := g.NewEdgeWithWeight("a", "b", 5); err != nil {
+			t.Errorf("expected `%v` got `%v`", nil, err)
+		}
+		if got, _ := g.Weight("b", "a"); got != 5 {
+			t.Errorf("expected `%v` got `%v`", 5, got)
+		}
+		if err := g.SetWeight("b", "a", -2); err != nil {
+			t.Errorf("expected `%v` got `%v`", nil, err)
+		}
+		if got, _ := g.Weight("a", "b"); got != -2 {
+			t.Errorf("expected `%v` got `%v`", -2, got)
+		}
+		g.NewEdge("a", "b")
+		if got, _ := g.Weight("a", "b"); got != -2 {
+			t.Errorf("expected `%v` got `%v`", -2, got)
+		}
+	})
+	t.Run("non-finite weight", func(t *testing.T) {
+		g := New()
+		g.NewNode("a", nil)
+		g.NewNode("b", nil)
+		for _, weight := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+			if err := g.NewEdgeWithWeight("a", "b", weight); err != ErrorInvalidWeight {
+				t.Errorf("expected `%v` got `%v`", ErrorInvalidWeight, err)
+			}
+		}
+		if g.HasEdge("a", "b") {
+			t.Errorf("unexpected edge `%v`-`%v`", "a", "b")
+		}
+		g.NewEdgeWithWeight("a", "b", 2)
+		for _, weight := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+			if err := g.SetWeight("a", "b", weight); err != ErrorInvalidWeight {
+				t.Errorf("expected `%v` got `%v`", ErrorInvalidWeight, err)
+			}
+		}
+		if got, _ := g.Weight("a", "b"); got != 2 {
+			t.Errorf("expected `%v` got `%v`", 2, got)
+		}
+	})
+	t.Run("unknown edges", func(t *testing.T) {
+		g := New()
+		g.NewNode("a", nil)
+		g.NewNode("b", nil)
+		if _, err := g.Weight("a", "b"); err != ErrorEdgeNotFound {
+			t.Errorf("expected `%v` got `%v`", ErrorEdgeNotFound, err)
+		}
+		if err := g.SetWeight("a", "b", 1); err != ErrorEdgeNotFound {
+			t.Errorf("expected `%v` got `%v`", ErrorEdgeNotFound, err)
+		}
+		if _, err := g.Weight("a", "x"); err != ErrorNodeNotFound {
+			t.Errorf("expected `%v` got `%v`", ErrorNodeNotFound, err)
+		}
+		if err := g.SetWeight("x", "a", 1); err != ErrorNodeNotFound {
+			t.Errorf("expected `%v` got `%v`", ErrorNodeNotFound, err)
+		}
+		if err := g.NewEdgeWithWeight("a", "x", 1); err != ErrorNodeNotFound {
+			t.Errorf("expected `%v` got `%v`", ErrorNodeNotFound, err)
+		}
+	})
+}
@@ -0,0 +1,211 @@
+package graphalg
+
+import (
+	"fmt"
+	"sort"
+)
+
+// ErrorNotEulerian is returned when a graph has no Eulerian path or circuit.
+// It is wrapped by an error describing the reason.
+var ErrorNotEulerian = fmt.Errorf("not eulerian")
+
+// sortedNodes returns the keys of all nodes in lexical order
+func sortedNodes(g Graph) []string {
+	keys := g.Nodes()
+	sort.Strings(keys)
+	return keys
+}
+
+// edgeComponents returns the number of connected components that contain at
+// least one edge. Edges are followed in both directions.
+func edgeComponents(keys []string, adj map[string][]string) int {
+	undirected := make(map[string][]string, len(keys))
+	for _, from := range keys {
+		for _, to := range adj[from] {
+			undirected[from] = append(undirected[from], to)
+			undirected[to] = append(undirected[to], from)
+		}
+	}
+	seen := make(map[string]bool)
+	components := 0
+	for _, key := range keys {
+		if seen[key] || len(undirected[key]) == 0 {
+			continue
+		}
+		components++
+		seen[key] = true
+		pending := []string{key}
+		for len(pending) > 0 {
+			from := pending[len(pending)-1]
+			pending = pending[:len(pending)-1]
+			for _, to := range undirected[from] {
+				if !seen[to] {
+					seen[to] = true
+					pending = append(pending, to)
+				}
+			}
+		}
+	}
+	return components
+}
+
+// hierholzer returns a walk using every edge exactly once, starting at start.
+// The next function returns the next unused edge leaving a node and marks it
+// as used, or false if all edges of the node have been used.
+func hierholzer(start string, next func(from string) (string, bool)) []string {
+	var walk []string
+	stack := []string{start}
+	for len(stack) > 0 {
+		from := stack[len(stack)-1]
+		if to, ok := next(from); ok {
+			stack = append(stack, to)
+			continue
+		}
+		stack = stack[:len(stack)-1]
+		walk = append(walk, from)
+	}
+	for l, r := 0, len(walk)-1; l < r; l, r = l+1, r-1 {
+		walk[l], walk[r] = walk[r], walk[l]
+	}
+	return walk
+}
+
+// undirectedEuler implements EulerianCircuit and EulerianPath
+func undirectedEuler(g Graph, circuit bool) ([]string, error) {
+	keys := sortedNodes(g)
+	adj := make(map[string][]string, len(keys))
+	var odd []string
+	var start string
+	hasEdges := false
+	for _, key := range keys {
+		adj[key], _ = g.Neighbors(key)
+		degree := len(adj[key])
+		for _, to := range adj[key] {
+			if to == key {
+				// a self-loop contributes two to the degree
+				degree++
+			}
+		}
+		if degree%2 == 1 {
+			odd = append(odd, key)
+		}
+		if degree > 0 && !hasEdges {
+			start = key
+			hasEdges = true
+		}
+	}
+	if len(odd) > 0 && (circuit || len(odd) > 2) {
+		return nil, fmt.Errorf("%w: nodes %v have an odd number of edges",
+			ErrorNotEulerian, odd)
+	}
+	if n := edgeComponents(keys, adj); n > 1 {
+		return nil, fmt.Errorf("%w: edges span %d components", ErrorNotEulerian, n)
+	}
+	if !hasEdges {
+		return nil, nil
+	}
+	if len(odd) > 0 {
+		start = odd[0]
+	}
+
+	used := make(map[[2]string]bool)
+	ptr := make(map[string]int, len(keys))
+	return hierholzer(start, func(from string) (string, bool) {
+		for ptr[from] < len(adj[from]) {
+			to := adj[from][ptr[from]]
+			ptr[from]++
+			edge := [2]string{from, to}
+			if to < from {
+				edge = [2]string{to, from}
+			}
+			if !used[edge] {
+				used[edge] = true
+				return to, true
+			}
+		}
+		return "", false
+	}), nil
+}
+
+// EulerianCircuit returns a closed walk through the undirected graph that uses
+// every edge exactly once, using Hierholzer's algorithm. The walk lists the
+// nodes along the way, starting and ending at the lexically smallest node with
+// edges. A graph without edges has an empty circuit. An error wrapping
+// ErrorNotEulerian and describing the reason is returned if there is no such
+// walk.
+func EulerianCircuit(g Graph) ([]string, error) {
+	return undirectedEuler(g, true)
+}
+
+// EulerianPath returns a walk through the undirected graph that uses every
+// edge exactly once, using Hierholzer's algorithm. If two nodes have an odd
+// number of edges, the walk starts at the lexically smaller one and ends at the
+// other, otherwise the walk is a circuit. An error wrapping ErrorNotEulerian
+// and describing the reason is returned if there is no such walk.
+func EulerianPath(g Graph) ([]string, error) {
+	return undirectedEuler(g, false)
+}
+
+// directedEuler implements DirectedEulerianCircuit and DirectedEulerianPath
+func directedEuler(g Digraph, circuit bool) ([]string, error) {
+	keys := sortedNodes(g)
+	adj := make(map[string][]string, len(keys))
+	var start string
+	hasEdges, hasSource, hasSink := false, false, false
+	for _, key := range keys {
+		adj[key], _ = g.Neighbors(key)
+		in, _ := g.Predecessors(key)
+		out := len(adj[key])
+		if out > 0 && !hasEdges {
+			start = key
+			hasEdges = true
+		}
+		switch {
+		case out == len(in):
+			continue
+		case !circuit && out == len(in)+1 && !hasSource:
+			// the walk has to start here
+			start = key
+			hasSource = true
+		case !circuit && len(in) == out+1 && !hasSink:
+			hasSink = true
+		default:
+			return nil, fmt.Errorf("%w: node %q has %d incoming and %d outgoing edges",
+				ErrorNotEulerian, key, len(in), out)
+		}
+	}
+	if n := edgeComponents(keys, adj); n > 1 {
+		return nil, fmt.Errorf("%w: edges span %d components", ErrorNotEulerian, n)
+	}
+	if !hasEdges {
+		return nil, nil
+	}
+
+	ptr := make(map[string]int, len(keys))
+	return hierholzer(start, func(from string) (string, bool) {
+		if ptr[from] == len(adj[from]) {
+			return "", false
+		}
+		ptr[from]++
+		return adj[from][ptr[from]-1], true
+	}), nil
+}
+
+// DirectedEulerianCircuit returns a closed walk through the directed graph
+// that follows every edge exactly once, using Hierholzer's algorithm. The walk
+// lists the nodes along the way, starting and ending at the lexically smallest
+// node with outgoing edges. A graph without edges has an empty circuit. An
+// error wrapping ErrorNotEulerian and describing the reason is returned if
+// there is no such walk.
+func DirectedEulerianCircuit(g Digraph) ([]string, error) {
+	return directedEuler(g, true)
+}
+
+// DirectedEulerianPath returns a walk through the directed graph that follows
+// every edge exactly once, using Hierholzer's algorithm. If a node has one
+// more outgoing than incoming edge, the walk starts there, otherwise the walk
+// is a circuit. An error wrapping ErrorNotEulerian and describing the reason
+// is returned if there is no such walk.
+func DirectedEulerianPath(g Digraph) ([]string, error) {
+	return directedEuler(g, false)
+}
@@ -0,0 +1,169 @@
+package graphalg
+
+import (
+	"errors"
+	"testing"
+)
+
+// usesEdgesOnce reports whether a walk uses every edge exactly once. Edges of
+// undirected graphs may be used in either direction.
+func usesEdgesOnce(g digraph, walk []string, directed bool) bool {
+	remaining := make(map[[2]string]int)
+	count := 0
+	for from, tos := range g {
+		for _, to := range tos {
+			if !directed && to < from {
+				continue
+			}
+			remaining[[2]string{from, to}]++
+			count++
+		}
+	}
+	if len(walk) != count+1 {
+		return false
+	}
+	for i := 1; i < len(walk); i++ {
+		edge := [2]string{walk[i-1], walk[i]}
+		if !directed && edge[1] < edge[0] {
+			edge = [2]string{edge[1], edge[0]}
+		}
+		if remaining[edge] == 0 {
+			return false
+		}
+		remaining[edge]--
+	}
+	return true
+}
+
+// house returns the undirected house graph, a square with a roof
+//
+//	  e
+//	 / \
+//	c - d
+//	|   |
+//	a - b
+func house() digraph {
+	return undirected([]string{"a", "b", "c", "d", "e"},
+		[2]string{"a", "b"}, [2]string{"a", "c"}, [2]string{"b", "d"},
+		[2]string{"c", "d"}, [2]string{"c", "e"}, [2]string{"d", "e"})
+}
+
+func TestEulerianCircuit(t *testing.T) {
+	t.Run("bowtie", func(t *testing.T) {
+		g := undirected([]string{"a", "b", "c", "d", "e"},
+			[2]string{"a", "b"}, [2]string{"b", "c"}, [2]string{"c", "a"},
+			[2]string{"c", "d"}, [2]string{"d", "e"}, [2]string{"e", "c"})
+		walk, err := EulerianCircuit(g)
+		if err != nil {
+			t.Fatalf("expected `%v` got `%v`", nil, err)
+		}
+		if walk[0] != "a" || walk[len(walk)-1] != "a" || !usesEdgesOnce(g, walk, false) {
+			t.Errorf("invalid circuit `%v`", walk)
+		}
+	})
+	t.Run("odd degrees", func(t *testing.T) {
+		_, err := EulerianCircuit(house())
+		if !errors.Is(err, ErrorNotEulerian) {
+			t.Errorf("expected `%v` got `%v`", ErrorNotEulerian, err)
+		}
+		expected := "not eulerian: nodes [c d] have an odd number of edges"
+		if err.Error() != expected {
+			t.Errorf("expected `%v` got `%v`", expected, err)
+		}
+	})
+	t.Run("disconnected edges", func(t *testing.T) {
+		g := undirected([]string{"a", "b", "c", "d", "e", "f", "z"},
+			[2]string{"a", "b"}, [2]string{"b", "c"}, [2]string{"c", "a"},
+			[2]string{"d", "e"}, [2]string{"e", "f"}, [2]string{"f", "d"})
+		_, err := EulerianCircuit(g)
+		expected := "not eulerian: edges span 2 components"
+		if !errors.Is(err, ErrorNotEulerian) || err.Error() != expected {
+			t.Errorf("expected `%v` got `%v`", expected, err)
+		}
+	})
+	t.Run("no edges", func(t *testing.T) {
+		walk, err := EulerianCircuit(newDigraph([]string{"a"}))
+		if err != nil || len(walk) != 0 {
+			t.Errorf("expected empty circuit got `%v` `%v`", walk, err)
+		}
+	})
+}
+
+func TestEulerianPath(t *testing.T) {
+	t.Run("house", func(t *testing.T) {
+		g := house()
+		walk, err := EulerianPath(g)
+		if err != nil {
+			t.Fatalf("expected `%v` got `%v`", nil, err)
+		}
+		if walk[0] != "c" || walk[len(walk)-1] != "d" || !usesEdgesOnce(g, walk, false) {
+			t.Errorf("invalid path `%v`", walk)
+		}
+	})
+	t.Run("too many odd degrees", func(t *testing.T) {
+		g := undirected([]string{"a", "b", "c", "d"},
+			[2]string{"a", "b"}, [2]string{"a", "c"}, [2]string{"a", "d"})
+		_, err := EulerianPath(g)
+		expected := "not eulerian: nodes [a b c d] have an odd number of edges"
+		if !errors.Is(err, ErrorNotEulerian) || err.Error() != expected {
+			t.Errorf("expected `%v` got `%v`", expected, err)
+		}
+	})
+}
+
+func TestDirectedEulerianCircuit(t *testing.T) {
+	t.Run("two cycles", func(t *testing.T) {
+		g := newDigraph([]string{"a", "b", "c", "d"},
+			[2]string{"a", "b"}, [2]string{"b", "c"}, [2]string{"c", "a"},
+			[2]string{"c", "d"}, [2]string{"d", "c"})
+		walk, err := DirectedEulerianCircuit(g)
+		if err != nil {
+			t.Fatalf("expected `%v` got `%v`", nil, err)
+		}
+		if walk[0] != "a" || walk[len(walk)-1] != "a" || !usesEdgesOnce(g, walk, true) {
+			t.Errorf("invalid circuit `%v`", walk)
+		}
+	})
+	t.Run("unbalanced node", func(t *testing.T) {
+		g := newDigraph([]string{"a", "b", "c"},
+			[2]string{"a", "b"}, [2]string{"b", "c"})
+		_, err := DirectedEulerianCircuit(g)
+		expected := `not eulerian: node "a" has 0 incoming and 1 outgoing edges`
+		if !errors.Is(err, ErrorNotEulerian) || err.Error() != expected {
+			t.Errorf("expected `%v` got `%v`", expected, err)
+		}
+	})
+}
+
+func TestDirectedEulerianPath(t *testing.T) {
+	t.Run("path with detour", func(t *testing.T) {
+		g := newDigraph([]string{"a", "b", "c", "d"},
+			[2]string{"b", "a"}, [2]string{"a", "c"}, [2]string{"c", "d"},
+			[2]string{"d", "a"}, [2]string{"a", "b"}, [2]string{"b", "c"})
+		walk, err := DirectedEulerianPath(g)
+		if err != nil {
+			t.Fatalf("expected `%v` got `%v`", nil, err)
+		}
+		if walk[0] != "b" || walk[len(walk)-1] != "c" || !usesEdgesOnce(g, walk, true) {
+			t.Errorf("invalid path `%v`", walk)
+		}
+	})
+	t.Run("two sources", func(t *testing.T) {
+		g := newDigraph([]string{"a", "b", "c"},
+			[2]string{"a", "c"}, [2]string{"b", "c"})
+		_, err := DirectedEulerianPath(g)
+		expected := `not eulerian: node "b" has 0 incoming and 1 outgoing edges`
+		if !errors.Is(err, ErrorNotEulerian) || err.Error() != expected {
+			t.Errorf("expected `%v` got `%v`", expected, err)
+		}
+	})
+	t.Run("disconnected edges", func(t *testing.T) {
+		g := newDigraph([]string{"a", "b", "c", "d"},
+			[2]string{"a", "b"}, [2]string{"b", "a"}, [2]string{"c", "d"},
+			[2]string{"d", "c"})
+		_, err := DirectedEulerianPath(g)
+		if !errors.Is(err, ErrorNotEulerian) {
+			t.Errorf("expected `%v` got `%v`", ErrorNotEulerian, err)
+		}
+	})
+}
@@ -0,0 +1,33 @@
+// Package graphalg implements graph algorithms once for both the undirected
+// graph of package graph and the directed graph of package directedgraph. The
+// algorithms access graphs through the read-only Graph and Digraph interfaces
+// only. They acquire no lock across calls, so modifications made while an
+// algorithm runs may or may not be observed.
+package graphalg
+
+import "fmt"
+
+// ErrorNoPath is returned when there is no path between two nodes
+var ErrorNoPath = fmt.Errorf("no path")
+
+// Graph is a read-only view of a graph. For directed graphs, the neighbors of
+// a node are its successors.
+type Graph interface {
+	// Nodes returns the keys of all nodes in the graph
+	Nodes() []string
+	// Neighbors returns the keys of the nodes adjacent to a node in lexical
+	// order, or an error if the node does not exist
+	Neighbors(key string) ([]string, error)
+	// HasEdge returns true if there is an edge between two nodes
+	HasEdge(from, to string) bool
+	// Value returns the value of a node, or an error if the node does not exist
+	Value(key string) (interface{}, error)
+}
+
+// Digraph is a read-only view of a directed graph
+type Digraph interface {
+	Graph
+	// Predecessors returns the keys of the nodes that have an edge pointing to
+	// a node in lexical order, or an error if the node does not exist
+	Predecessors(key string) ([]string, error)
+}
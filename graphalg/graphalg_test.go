@@ -0,0 +1,92 @@
+package graphalg
+
+import (
+	"fmt"
+	"sort"
+)
+
+var errorNodeNotFound = fmt.Errorf("node not found")
+
+// digraph is a minimal directed graph implementing Digraph
+type digraph map[string][]string
+
+// newDigraph returns a directed graph with the given nodes and edges
+func newDigraph(keys []string, edges ...[2]string) digraph {
+	d := make(digraph)
+	for _, key := range keys {
+		d[key] = nil
+	}
+	for _, e := range edges {
+		d[e[0]] = append(d[e[0]], e[1])
+		sort.Strings(d[e[0]])
+	}
+	return d
+}
+
+// undirected returns a directed graph with edges in both directions for each
+// given edge
+func undirected(keys []string, edges ...[2]string) digraph {
+	var both [][2]string
+	for _, e := range edges {
+		both = append(both, e, [2]string{e[1], e[0]})
+	}
+	return newDigraph(keys, both...)
+}
+
+func (d digraph) Nodes() []string {
+	var keys []string
+	for key := range d {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func (d digraph) Neighbors(key string) ([]string, error) {
+	to, ok := d[key]
+	if !ok {
+		return nil, errorNodeNotFound
+	}
+	return to, nil
+}
+
+func (d digraph) HasEdge(from, to string) bool {
+	for _, key := range d[from] {
+		if key == to {
+			return true
+		}
+	}
+	return false
+}
+
+func (d digraph) Value(key string) (interface{}, error) {
+	if _, ok := d[key]; !ok {
+		return nil, errorNodeNotFound
+	}
+	return key, nil
+}
+
+func (d digraph) Predecessors(key string) ([]string, error) {
+	if _, ok := d[key]; !ok {
+		return nil, errorNodeNotFound
+	}
+	var from []string
+	for other := range d {
+		if d.HasEdge(other, key) {
+			from = append(from, other)
+		}
+	}
+	sort.Strings(from)
+	return from, nil
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,184 @@
+package graphalg
+
+import (
+	"fmt"
+	"sort"
+)
+
+var (
+	// ErrorNotHamiltonian is returned when a graph has no Hamiltonian path
+	ErrorNotHamiltonian = fmt.Errorf("not hamiltonian")
+	// ErrorBudgetExhausted is returned when a search gave up before finding a
+	// result or proving that there is none
+	ErrorBudgetExhausted = fmt.Errorf("budget exhausted")
+)
+
+// HeldKarpLimit is the largest number of nodes for which HamiltonianPath uses
+// dynamic programming over subsets of nodes. The table takes 2^n words.
+const HeldKarpLimit = 20
+
+// HamiltonianPath returns a path that visits every node of the graph exactly
+// once. For directed graphs the path follows the direction of the edges.
+// Graphs with up to HeldKarpLimit nodes are solved exactly by dynamic
+// programming over subsets of nodes. Larger graphs are searched by
+// backtracking, preferring neighbors with few unvisited neighbors of their own,
+// which gives up with ErrorBudgetExhausted after budget steps.
+// ErrorNotHamiltonian is returned if there is no such path.
+func HamiltonianPath(g Graph, budget int) ([]string, error) {
+	keys := sortedNodes(g)
+	if len(keys) == 0 {
+		return nil, nil
+	}
+	index := make(map[string]int, len(keys))
+	for i, key := range keys {
+		index[key] = i
+	}
+	adj := make([][]int, len(keys))
+	for i, key := range keys {
+		neighbors, _ := g.Neighbors(key)
+		for _, to := range neighbors {
+			if j, ok := index[to]; ok && j != i {
+				adj[i] = append(adj[i], j)
+			}
+		}
+	}
+
+	var path []int
+	var err error
+	if len(keys) <= HeldKarpLimit {
+		path, err = heldKarp(adj)
+	} else {
+		path, err = backtrack(adj, budget)
+	}
+	if err != nil {
+		return nil, err
+	}
+	out := make([]string, len(path))
+	for i, id := range path {
+		out[i] = keys[id]
+	}
+	return out, nil
+}
+
+// heldKarp finds a Hamiltonian path by dynamic programming. ends[mask] is the
+// set of nodes at which a path visiting exactly the nodes in mask can end.
+func heldKarp(adj [][]int) ([]int, error) {
+	n := len(adj)
+	full := uint32(1)<<uint(n) - 1
+	successors := make([]uint32, n)
+	for v, tos := range adj {
+		for _, w := range tos {
+			successors[v] |= 1 << uint(w)
+		}
+	}
+	ends := make([]uint32, full+1)
+	for v := 0; v < n; v++ {
+		ends[1<<uint(v)] = 1 << uint(v)
+	}
+	for mask := uint32(1); mask < full; mask++ {
+		for v := 0; v < n; v++ {
+			if ends[mask]&(1<<uint(v)) == 0 {
+				continue
+			}
+			next := successors[v] &^ mask
+			for w := 0; w < n; w++ {
+				if next&(1<<uint(w)) != 0 {
+					ends[mask|1<<uint(w)] |= 1 << uint(w)
+				}
+			}
+		}
+	}
+	if ends[full] == 0 {
+		return nil, ErrorNotHamiltonian
+	}
+
+	// walk backwards from the smallest possible end
+	path := make([]int, n)
+	mask := full
+	last := -1
+	for i := n - 1; i >= 0; i-- {
+		for v := 0; v < n; v++ {
+			if ends[mask]&(1<<uint(v)) != 0 &&
+				(last < 0 || successors[v]&(1<<uint(last)) != 0) {
+				path[i] = v
+				last = v
+				break
+			}
+		}
+		mask &^= 1 << uint(last)
+	}
+	return path, nil
+}
+
+// backtrack searches a Hamiltonian path depth first from every start node,
+// trying neighbors with the fewest unvisited neighbors first (Warnsdorff's
+// rule). Every extension of the path counts as one step of the budget.
+func backtrack(adj [][]int, budget int) ([]int, error) {
+	n := len(adj)
+	visited := make([]bool, n)
+	path := make([]int, 0, n)
+	steps := 0
+
+	// unvisited counts the unvisited neighbors of a node
+	unvisited := func(v int) int {
+		count := 0
+		for _, w := range adj[v] {
+			if !visited[w] {
+				count++
+			}
+		}
+		return count
+	}
+	var extend func(v int) (bool, error)
+	extend = func(v int) (bool, error) {
+		if steps >= budget {
+			return false, ErrorBudgetExhausted
+		}
+		steps++
+		visited[v] = true
+		path = append(path, v)
+		if len(path) == n {
+			return true, nil
+		}
+		var candidates []int
+		for _, w := range adj[v] {
+			if !visited[w] {
+				candidates = append(candidates, w)
+			}
+		}
+		degree := make(map[int]int, len(candidates))
+		for _, w := range candidates {
+			degree[w] = unvisited(w)
+		}
+		sort.SliceStable(candidates, func(i, j int) bool {
+			return degree[candidates[i]] < degree[candidates[j]]
+		})
+		for _, w := range candidates {
+			if ok, err := extend(w); ok || err != nil {
+				return ok, err
+			}
+		}
+		visited[v] = false
+		path = path[:len(path)-1]
+		return false, nil
+	}
+
+	// nodes with few edges are likely ends of the path
+	starts := make([]int, n)
+	for v := range starts {
+		starts[v] = v
+	}
+	sort.SliceStable(starts, func(i, j int) bool {
+		return len(adj[starts[i]]) < len(adj[starts[j]])
+	})
+	for _, v := range starts {
+		ok, err := extend(v)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			return path, nil
+		}
+	}
+	return nil, ErrorNotHamiltonian
+}
@@ -0,0 +1,126 @@
+package graphalg
+
+import (
+	"strconv"
+	"testing"
+)
+
+// visitsNodesOnce reports whether a path visits every node exactly once along
+// edges of the graph
+func visitsNodesOnce(g digraph, path []string) bool {
+	if len(path) != len(g) {
+		return false
+	}
+	seen := make(map[string]bool)
+	for i, key := range path {
+		if _, ok := g[key]; !ok || seen[key] {
+			return false
+		}
+		seen[key] = true
+		if i > 0 && !g.HasEdge(path[i-1], key) {
+			return false
+		}
+	}
+	return true
+}
+
+// knightGraph returns the graph of knight moves on a board with the given
+// number of rows and columns
+func knightGraph(rows, cols int) digraph {
+	var keys []string
+	var edges [][2]string
+	for i := 0; i < rows*cols; i++ {
+		keys = append(keys, strconv.Itoa(i))
+		r, c := i/cols, i%cols
+		for _, d := range [][2]int{{1, 2}, {2, 1}, {2, -1}, {1, -2}} {
+			if rr, cc := r+d[0], c+d[1]; rr >= 0 && rr < rows && cc >= 0 && cc < cols {
+				edges = append(edges, [2]string{strconv.Itoa(i),
+					strconv.Itoa(rr*cols + cc)})
+			}
+		}
+	}
+	return undirected(keys, edges...)
+}
+
+func TestHamiltonianPath(t *testing.T) {
+	t.Run("empty graph", func(t *testing.T) {
+		path, err := HamiltonianPath(newDigraph(nil), 0)
+		if err != nil || len(path) != 0 {
+			t.Errorf("expected empty path got `%v` `%v`", path, err)
+		}
+	})
+	t.Run("house", func(t *testing.T) {
+		g := house()
+		path, err := HamiltonianPath(g, 0)
+		if err != nil {
+			t.Fatalf("expected `%v` got `%v`", nil, err)
+		}
+		if !visitsNodesOnce(g, path) {
+			t.Errorf("invalid path `%v`", path)
+		}
+	})
+	t.Run("directed graph", func(t *testing.T) {
+		g := newDigraph([]string{"a", "b", "c", "d"},
+			[2]string{"c", "a"}, [2]string{"a", "d"}, [2]string{"d", "b"},
+			[2]string{"b", "a"})
+		path, err := HamiltonianPath(g, 0)
+		if err != nil {
+			t.Fatalf("expected `%v` got `%v`", nil, err)
+		}
+		expected := []string{"c", "a", "d", "b"}
+		if !equal(expected, path) {
+			t.Errorf("expected `%v` got `%v`", expected, path)
+		}
+	})
+	t.Run("no path", func(t *testing.T) {
+		// a star with three leaves has no Hamiltonian path
+		g := undirected([]string{"a", "b", "c", "d"},
+			[2]string{"a", "b"}, [2]string{"a", "c"}, [2]string{"a", "d"})
+		if _, err := HamiltonianPath(g, 0); err != ErrorNotHamiltonian {
+			t.Errorf("expected `%v` got `%v`", ErrorNotHamiltonian, err)
+		}
+	})
+	t.Run("knight's tour", func(t *testing.T) {
+		g := knightGraph(3, 4)
+		path, err := HamiltonianPath(g, 0)
+		if err != nil {
+			t.Fatalf("expected `%v` got `%v`", nil, err)
+		}
+		if !visitsNodesOnce(g, path) {
+			t.Errorf("invalid path `%v`", path)
+		}
+		// there is no knight's tour on a 4x4 board
+		if _, err := HamiltonianPath(knightGraph(4, 4), 0); err != ErrorNotHamiltonian {
+			t.Errorf("expected `%v` got `%v`", ErrorNotHamiltonian, err)
+		}
+	})
+	t.Run("backtracking", func(t *testing.T) {
+		g := knightGraph(8, 8)
+		path, err := HamiltonianPath(g, 1000000)
+		if err != nil {
+			t.Fatalf("expected `%v` got `%v`", nil, err)
+		}
+		if !visitsNodesOnce(g, path) {
+			t.Errorf("invalid path `%v`", path)
+		}
+	})
+	t.Run("budget exhausted", func(t *testing.T) {
+		if _, err := HamiltonianPath(knightGraph(8, 8), 10); err != ErrorBudgetExhausted {
+			t.Errorf("expected `%v` got `%v`", ErrorBudgetExhausted, err)
+		}
+	})
+	t.Run("no path found by backtracking", func(t *testing.T) {
+		// two disjoint cycles
+		var keys []string
+		var edges [][2]string
+		for i := 0; i < 2*HeldKarpLimit; i++ {
+			keys = append(keys, strconv.Itoa(i))
+			edges = append(edges, [2]string{strconv.Itoa(i),
+				strconv.Itoa(i/HeldKarpLimit*HeldKarpLimit + (i+1)%HeldKarpLimit)})
+		}
+		g := undirected(keys, edges...)
+		if _, err := HamiltonianPath(g, 1000000); err != ErrorNotHamiltonian {
+			t.Errorf("expected `%v` got `%v`", ErrorNotHamiltonian, err)
+		}
+	})
+}
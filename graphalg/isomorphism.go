@@ -0,0 +1,356 @@
+package graphalg
+
+import (
+	"fmt"
+	"sort"
+)
+
+// ErrorNotIsomorphic is returned when two graphs are not isomorphic
+var ErrorNotIsomorphic = fmt.Errorf("not isomorphic")
+
+// NodeMatch reports whether two node values are considered equal when
+// matching graphs. The first value is taken from the first graph or the
+// pattern, the second one from the second graph or the target.
+type NodeMatch func(a, b interface{}) bool
+
+// indexed is a graph with nodes numbered in lexical order of their keys
+type indexed struct {
+	keys []string
+	succ [][]int
+	pred [][]int
+	out  []map[int]bool
+	// values is nil unless node values are compared
+	values []interface{}
+}
+
+// newIndexed returns the indexed form of a graph. Node values are only read if
+// values is true.
+func newIndexed(g Graph, values bool) *indexed {
+	keys := sortedNodes(g)
+	index := make(map[string]int, len(keys))
+	for i, key := range keys {
+		index[key] = i
+	}
+	x := &indexed{
+		keys: keys,
+		succ: make([][]int, len(keys)),
+		pred: make([][]int, len(keys)),
+		out:  make([]map[int]bool, len(keys)),
+	}
+	for i, key := range keys {
+		x.out[i] = make(map[int]bool)
+		neighbors, _ := g.Neighbors(key)
+		for _, to := range neighbors {
+			// nodes added concurrently after the call to Nodes are ignored
+			if j, ok := index[to]; ok {
+				x.succ[i] = append(x.succ[i], j)
+				x.pred[j] = append(x.pred[j], i)
+				x.out[i][j] = true
+			}
+		}
+	}
+	if values {
+		x.values = make([]interface{}, len(keys))
+		for i, key := range keys {
+			x.values[i], _ = g.Value(key)
+		}
+	}
+	return x
+}
+
+// vf2 holds the state of a VF2 search for mappings from the nodes of g1 to
+// the nodes of g2
+type vf2 struct {
+	g1, g2 *indexed
+	match  NodeMatch
+	// subgraph selects induced subgraph isomorphism instead of isomorphism
+	subgraph bool
+	// core1 and core2 hold the mapped node of every node, or -1
+	core1, core2 []int
+	// in and out hold the depth at which a node joined the set of mapped nodes
+	// and their predecessors or successors, respectively, or zero
+	in1, out1, in2, out2 []int
+	depth                int
+}
+
+// newVF2 returns the initial state of a VF2 search
+func newVF2(g1, g2 *indexed, match NodeMatch, subgraph bool) *vf2 {
+	s := &vf2{
+		g1:       g1,
+		g2:       g2,
+		match:    match,
+		subgraph: subgraph,
+		core1:    make([]int, len(g1.keys)),
+		core2:    make([]int, len(g2.keys)),
+		in1:      make([]int, len(g1.keys)),
+		out1:     make([]int, len(g1.keys)),
+		in2:      make([]int, len(g2.keys)),
+		out2:     make([]int, len(g2.keys)),
+	}
+	for i := range s.core1 {
+		s.core1[i] = -1
+	}
+	for i := range s.core2 {
+		s.core2[i] = -1
+	}
+	return s
+}
+
+// candidates returns the next node of g1 to map and the nodes of g2 it may be
+// mapped to. Nodes adjacent to the mapped nodes are preferred, since they have
+// to be mapped to nodes adjacent to the mapped nodes of g2.
+func (s *vf2) candidates() (int, []int) {
+	terminal := func(core, set []int) []int {
+		var nodes []int
+		for i := range core {
+			if core[i] < 0 && set[i] > 0 {
+				nodes = append(nodes, i)
+			}
+		}
+		return nodes
+	}
+	if t1 := terminal(s.core1, s.out1); len(t1) > 0 {
+		return t1[0], terminal(s.core2, s.out2)
+	}
+	if t1 := terminal(s.core1, s.in1); len(t1) > 0 {
+		return t1[0], terminal(s.core2, s.in2)
+	}
+	n := -1
+	for i := range s.core1 {
+		if s.core1[i] < 0 {
+			n = i
+			break
+		}
+	}
+	var ms []int
+	for i := range s.core2 {
+		if s.core2[i] < 0 {
+			ms = append(ms, i)
+		}
+	}
+	return n, ms
+}
+
+// feasible reports whether n can be mapped to m, keeping the mapping
+// consistent and leaving enough unmapped neighbors to complete it
+func (s *vf2) feasible(n, m int) bool {
+	if s.match != nil && !s.match(s.g1.values[n], s.g2.values[m]) {
+		return false
+	}
+	if s.g1.out[n][n] != s.g2.out[m][m] {
+		return false
+	}
+	// count unmapped successors and predecessors by the sets they belong to
+	var count1, count2 [6]int
+	classify := func(count *[6]int, offset, x int, in, out []int) {
+		if in[x] > 0 {
+			count[offset]++
+		}
+		if out[x] > 0 {
+			count[offset+1]++
+		}
+		if in[x] == 0 && out[x] == 0 {
+			count[offset+2]++
+		}
+	}
+	for _, x := range s.g1.succ[n] {
+		if x == n {
+			continue
+		}
+		if y := s.core1[x]; y >= 0 {
+			if !s.g2.out[m][y] {
+				return false
+			}
+		} else {
+			classify(&count1, 0, x, s.in1, s.out1)
+		}
+	}
+	for _, x := range s.g1.pred[n] {
+		if x == n {
+			continue
+		}
+		if y := s.core1[x]; y >= 0 {
+			if !s.g2.out[y][m] {
+				return false
+			}
+		} else {
+			classify(&count1, 3, x, s.in1, s.out1)
+		}
+	}
+	for _, y := range s.g2.succ[m] {
+		if y == m {
+			continue
+		}
+		if x := s.core2[y]; x >= 0 {
+			if !s.g1.out[n][x] {
+				return false
+			}
+		} else {
+			classify(&count2, 0, y, s.in2, s.out2)
+		}
+	}
+	for _, y := range s.g2.pred[m] {
+		if y == m {
+			continue
+		}
+		if x := s.core2[y]; x >= 0 {
+			if !s.g1.out[x][n] {
+				return false
+			}
+		} else {
+			classify(&count2, 3, y, s.in2, s.out2)
+		}
+	}
+	for i := range count1 {
+		if count1[i] > count2[i] || !s.subgraph && count1[i] != count2[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// mark adds a node and its neighbors to the terminal sets at the current depth
+func mark(g *indexed, in, out []int, n, depth int) {
+	if in[n] == 0 {
+		in[n] = depth
+	}
+	if out[n] == 0 {
+		out[n] = depth
+	}
+	for _, x := range g.succ[n] {
+		if out[x] == 0 {
+			out[x] = depth
+		}
+	}
+	for _, x := range g.pred[n] {
+		if in[x] == 0 {
+			in[x] = depth
+		}
+	}
+}
+
+// unmark removes everything mark added at the given depth
+func unmark(g *indexed, in, out []int, n, depth int) {
+	if in[n] == depth {
+		in[n] = 0
+	}
+	if out[n] == depth {
+		out[n] = 0
+	}
+	for _, x := range g.succ[n] {
+		if out[x] == depth {
+			out[x] = 0
+		}
+	}
+	for _, x := range g.pred[n] {
+		if in[x] == depth {
+			in[x] = 0
+		}
+	}
+}
+
+// search extends the mapping recursively and calls yield for every complete
+// mapping. It returns false if yield asked to stop.
+func (s *vf2) search(yield func(core []int) bool) bool {
+	if s.depth == len(s.g1.keys) {
+		return yield(s.core1)
+	}
+	n, ms := s.candidates()
+	for _, m := range ms {
+		if !s.feasible(n, m) {
+			continue
+		}
+		s.depth++
+		s.core1[n], s.core2[m] = m, n
+		mark(s.g1, s.in1, s.out1, n, s.depth)
+		mark(s.g2, s.in2, s.out2, m, s.depth)
+		ok := s.search(yield)
+		unmark(s.g1, s.in1, s.out1, n, s.depth)
+		unmark(s.g2, s.in2, s.out2, m, s.depth)
+		s.core1[n], s.core2[m] = -1, -1
+		s.depth--
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// mapping translates a VF2 core into a mapping of node keys
+func mapping(g1, g2 *indexed, core []int) map[string]string {
+	m := make(map[string]string, len(core))
+	for n, to := range core {
+		m[g1.keys[n]] = g2.keys[to]
+	}
+	return m
+}
+
+// degrees returns the sorted out-degree and in-degree sequences of a graph
+func degrees(g *indexed) ([]int, []int) {
+	out := make([]int, len(g.keys))
+	in := make([]int, len(g.keys))
+	for i := range g.keys {
+		out[i] = len(g.succ[i])
+		in[i] = len(g.pred[i])
+	}
+	sort.Ints(out)
+	sort.Ints(in)
+	return out, in
+}
+
+// equalInts returns true if two int slices are equal
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Isomorphic returns a mapping from the nodes of graph a to the nodes of
+// graph b that preserves edges in both directions, found with the VF2
+// algorithm by Cordella et al. For directed graphs the direction of edges is
+// preserved as well. If match is not nil, nodes are only mapped to nodes with
+// matching values. ErrorNotIsomorphic is returned if there is no such mapping.
+func Isomorphic(a, b Graph, match NodeMatch) (map[string]string, error) {
+	g1 := newIndexed(a, match != nil)
+	g2 := newIndexed(b, match != nil)
+	out1, in1 := degrees(g1)
+	out2, in2 := degrees(g2)
+	if !equalInts(out1, out2) || !equalInts(in1, in2) {
+		return nil, ErrorNotIsomorphic
+	}
+	var found map[string]string
+	newVF2(g1, g2, match, false).search(func(core []int) bool {
+		found = mapping(g1, g2, core)
+		return false
+	})
+	if found == nil {
+		return nil, ErrorNotIsomorphic
+	}
+	return found, nil
+}
+
+// SubgraphMatches calls yield for every mapping from the nodes of the pattern
+// to nodes of the target under which the pattern is isomorphic to the
+// subgraph induced by the mapped nodes, i.e. two pattern nodes are connected
+// if and only if the target nodes they are mapped to are connected. Mappings
+// are found with the VF2 algorithm by Cordella et al. A pattern with
+// symmetries matches the same target nodes under several mappings. If match
+// is not nil, pattern nodes are only mapped to target nodes with matching
+// values. The enumeration stops early if yield returns false. The yield
+// function owns the mapping passed to it.
+func SubgraphMatches(pattern, target Graph, match NodeMatch, yield func(map[string]string) bool) {
+	g1 := newIndexed(pattern, match != nil)
+	g2 := newIndexed(target, match != nil)
+	if len(g1.keys) > len(g2.keys) {
+		return
+	}
+	newVF2(g1, g2, match, true).search(func(core []int) bool {
+		return yield(mapping(g1, g2, core))
+	})
+}
@@ -0,0 +1,190 @@
+package graphalg
+
+import (
+	"testing"
+)
+
+// preserves returns true if mapping is a bijection between the nodes of a
+// and the nodes of b that maps edges to edges and non-edges to non-edges
+func preserves(a, b digraph, mapping map[string]string) bool {
+	if len(mapping) != len(a) || len(a) != len(b) {
+		return false
+	}
+	image := make(map[string]bool)
+	for _, to := range mapping {
+		image[to] = true
+	}
+	if len(image) != len(b) {
+		return false
+	}
+	for from := range a {
+		for to := range a {
+			if a.HasEdge(from, to) != b.HasEdge(mapping[from], mapping[to]) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// relabel returns a copy of a graph with every node key prefixed
+func relabel(d digraph, prefix string) digraph {
+	r := make(digraph, len(d))
+	for from, tos := range d {
+		r[prefix+from] = nil
+		for _, to := range tos {
+			r[prefix+from] = append(r[prefix+from], prefix+to)
+		}
+	}
+	return r
+}
+
+func TestIsomorphic(t *testing.T) {
+	t.Run("isomorphic graphs", func(t *testing.T) {
+		tt := []struct {
+			name string
+			a, b digraph
+		}{
+			{name: "empty graphs", a: newDigraph(nil), b: newDigraph(nil)},
+			{name: "relabeled house", a: house(), b: relabel(house(), "x")},
+			{name: "relabeled diamond", a: diamond(), b: relabel(diamond(), "x")},
+			{
+				name: "rotated square",
+				a: undirected([]string{"a", "b", "c", "d"}, [2]string{"a", "b"},
+					[2]string{"b", "c"}, [2]string{"c", "d"}, [2]string{"d", "a"}),
+				b: undirected([]string{"a", "b", "c", "d"}, [2]string{"a", "c"},
+					[2]string{"c", "b"}, [2]string{"b", "d"}, [2]string{"d", "a"}),
+			},
+			{
+				name: "reversed path",
+				a:    newDigraph([]string{"a", "b", "c"}, [2]string{"a", "b"}, [2]string{"b", "c"}),
+				b:    newDigraph([]string{"a", "b", "c"}, [2]string{"c", "b"}, [2]string{"b", "a"}),
+			},
+		}
+		for _, tc := range tt {
+			t.Run(tc.name, func(t *testing.T) {
+				got, err := Isomorphic(tc.a, tc.b, nil)
+				if err != nil {
+					t.Errorf("expected `%v` got `%v`", nil, err)
+				}
+				if !preserves(tc.a, tc.b, got) {
+					t.Errorf("unexpected mapping `%v`", got)
+				}
+			})
+		}
+	})
+	t.Run("non-isomorphic graphs", func(t *testing.T) {
+		keys := []string{"a", "b", "c", "d", "e", "f"}
+		tt := []struct {
+			name string
+			a, b digraph
+		}{
+			{
+				name: "hexagon and triangles",
+				a: undirected(keys, [2]string{"a", "b"}, [2]string{"b", "c"},
+					[2]string{"c", "d"}, [2]string{"d", "e"}, [2]string{"e", "f"},
+					[2]string{"f", "a"}),
+				b: undirected(keys, [2]string{"a", "b"}, [2]string{"b", "c"},
+					[2]string{"c", "a"}, [2]string{"d", "e"}, [2]string{"e", "f"},
+					[2]string{"f", "d"}),
+			},
+			{
+				name: "cycle and transitive triangle",
+				a: newDigraph(keys[:3], [2]string{"a", "b"}, [2]string{"b", "c"},
+					[2]string{"c", "a"}),
+				b: newDigraph(keys[:3], [2]string{"a", "b"}, [2]string{"b", "c"},
+					[2]string{"a", "c"}),
+			},
+			{
+				name: "self-loop",
+				a:    newDigraph(keys[:2], [2]string{"a", "a"}, [2]string{"a", "b"}),
+				b:    newDigraph(keys[:2], [2]string{"a", "b"}, [2]string{"b", "b"}),
+			},
+			{name: "different size", a: house(), b: diamond()},
+		}
+		for _, tc := range tt {
+			t.Run(tc.name, func(t *testing.T) {
+				if _, err := Isomorphic(tc.a, tc.b, nil); err != ErrorNotIsomorphic {
+					t.Errorf("expected `%v` got `%v`", ErrorNotIsomorphic, err)
+				}
+			})
+		}
+	})
+	t.Run("matching values", func(t *testing.T) {
+		// the node values of the fixture are the node keys
+		same := func(a, b interface{}) bool { return a == b }
+		got, err := Isomorphic(house(), house(), same)
+		if err != nil {
+			t.Errorf("expected `%v` got `%v`", nil, err)
+		}
+		for from, to := range got {
+			if from != to {
+				t.Errorf("expected `%v` got `%v`", from, to)
+			}
+		}
+		never := func(a, b interface{}) bool { return false }
+		if _, err := Isomorphic(house(), house(), never); err != ErrorNotIsomorphic {
+			t.Errorf("expected `%v` got `%v`", ErrorNotIsomorphic, err)
+		}
+	})
+}
+
+func TestSubgraphMatches(t *testing.T) {
+	count := func(pattern, target digraph, match NodeMatch) int {
+		n := 0
+		SubgraphMatches(pattern, target, match, func(mapping map[string]string) bool {
+			for from := range pattern {
+				for to := range pattern {
+					if pattern.HasEdge(from, to) != target.HasEdge(mapping[from], mapping[to]) {
+						t.Errorf("mapping `%v` does not preserve edges", mapping)
+					}
+				}
+			}
+			n++
+			return true
+		})
+		return n
+	}
+	keys := []string{"x", "y", "z"}
+	triangle := undirected(keys, [2]string{"x", "y"}, [2]string{"y", "z"},
+		[2]string{"z", "x"})
+	path := undirected(keys, [2]string{"x", "y"}, [2]string{"y", "z"})
+	edge := newDigraph(keys[:2], [2]string{"x", "y"})
+	loop := newDigraph(keys[:2], [2]string{"x", "y"}, [2]string{"y", "x"})
+	tt := []struct {
+		name            string
+		pattern, target digraph
+		expected        int
+	}{
+		{name: "triangles in house", pattern: triangle, target: house(), expected: 6},
+		// paths c-a-b, a-b-d, a-c-d, a-c-e, b-d-c and b-d-e in both directions
+		{name: "paths in house", pattern: path, target: house(), expected: 12},
+		{name: "edges in diamond", pattern: edge, target: diamond(), expected: 5},
+		{name: "cycles in diamond", pattern: loop, target: diamond(), expected: 2},
+		{name: "empty pattern", pattern: newDigraph(nil), target: house(), expected: 1},
+		{name: "pattern too large", pattern: diamond(), target: house(), expected: 0},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := count(tc.pattern, tc.target, nil); got != tc.expected {
+				t.Errorf("expected `%v` got `%v`", tc.expected, got)
+			}
+		})
+	}
+	t.Run("matching values", func(t *testing.T) {
+		top := func(a, b interface{}) bool { return a != "x" || b == "e" }
+		if got := count(triangle, house(), top); got != 2 {
+			t.Errorf("expected `%v` got `%v`", 2, got)
+		}
+	})
+	t.Run("stop early", func(t *testing.T) {
+		calls := 0
+		SubgraphMatches(triangle, house(), nil, func(map[string]string) bool {
+			calls++
+			return false
+		})
+		if calls != 1 {
+			t.Errorf("expected `%v` got `%v`", 1, calls)
+		}
+	})
+}
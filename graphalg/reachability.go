@@ -0,0 +1,83 @@
+package graphalg
+
+import "sort"
+
+// reach collects the keys of all nodes reachable from key by following the
+// adjacency function, leaving out key unless it is part of a cycle. The walk
+// stops as soon as stop returns true for a newly reached node.
+func reach(adjacent func(key string) ([]string, error), key string,
+	stop func(key string) bool) []string {
+	seen := make(map[string]bool)
+	var reached []string
+	pending := []string{key}
+	for len(pending) > 0 {
+		from := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		next, _ := adjacent(from)
+		for _, to := range next {
+			if seen[to] {
+				continue
+			}
+			seen[to] = true
+			reached = append(reached, to)
+			if stop != nil && stop(to) {
+				return reached
+			}
+			pending = append(pending, to)
+		}
+	}
+	return reached
+}
+
+// sortedWithout returns the keys in lexical order, leaving out exclude
+func sortedWithout(keys []string, exclude string) []string {
+	result := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if key != exclude {
+			result = append(result, key)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// Descendants returns the keys of all nodes that can be reached from the node
+// identified by key, in lexical order. The node itself is never part of the
+// result, even if it is part of a cycle.
+func Descendants(g Graph, key string) ([]string, error) {
+	if _, err := g.Value(key); err != nil {
+		return nil, err
+	}
+	return sortedWithout(reach(g.Neighbors, key, nil), key), nil
+}
+
+// Ancestors returns the keys of all nodes from which the node identified by
+// key can be reached, in lexical order. The node itself is never part of the
+// result, even if it is part of a cycle.
+func Ancestors(g Digraph, key string) ([]string, error) {
+	if _, err := g.Value(key); err != nil {
+		return nil, err
+	}
+	return sortedWithout(reach(g.Predecessors, key, nil), key), nil
+}
+
+// Reachable returns true if there is a path leading from one node to another.
+// Every node is reachable from itself. The search stops as soon as it reaches
+// the destination.
+func Reachable(g Graph, from, to string) (bool, error) {
+	if _, err := g.Value(from); err != nil {
+		return false, err
+	}
+	if _, err := g.Value(to); err != nil {
+		return false, err
+	}
+	if from == to {
+		return true, nil
+	}
+	found := false
+	reach(g.Neighbors, from, func(key string) bool {
+		found = key == to
+		return found
+	})
+	return found, nil
+}
@@ -0,0 +1,107 @@
+package graphalg
+
+import (
+	"testing"
+)
+
+// diamond returns a directed graph with a diamond and a cycle
+//
+//	a -> b -> d -> e <-> f
+//	a -> c -> d
+func diamond() digraph {
+	return newDigraph([]string{"a", "b", "c", "d", "e", "f", "z"},
+		[2]string{"a", "b"}, [2]string{"a", "c"}, [2]string{"b", "d"},
+		[2]string{"c", "d"}, [2]string{"d", "e"}, [2]string{"e", "f"},
+		[2]string{"f", "e"})
+}
+
+func TestDescendants(t *testing.T) {
+	tt := []struct {
+		key      string
+		expected []string
+	}{
+		{key: "a", expected: []string{"b", "c", "d", "e", "f"}},
+		{key: "e", expected: []string{"f"}},
+		{key: "z", expected: []string{}},
+	}
+	for _, tc := range tt {
+		got, err := Descendants(diamond(), tc.key)
+		if err != nil {
+			t.Errorf("expected `%v` got `%v`", nil, err)
+		}
+		if !equal(tc.expected, got) {
+			t.Errorf("node `%v`: expected `%v` got `%v`", tc.key, tc.expected, got)
+		}
+	}
+	if _, err := Descendants(diamond(), "x"); err != errorNodeNotFound {
+		t.Errorf("expected `%v` got `%v`", errorNodeNotFound, err)
+	}
+}
+
+func TestAncestors(t *testing.T) {
+	tt := []struct {
+		key      string
+		expected []string
+	}{
+		{key: "d", expected: []string{"a", "b", "c"}},
+		{key: "e", expected: []string{"a", "b", "c", "d", "f"}},
+		{key: "a", expected: []string{}},
+	}
+	for _, tc := range tt {
+		got, err := Ancestors(diamond(), tc.key)
+		if err != nil {
+			t.Errorf("expected `%v` got `%v`", nil, err)
+		}
+		if !equal(tc.expected, got) {
+			t.Errorf("node `%v`: expected `%v` got `%v`", tc.key, tc.expected, got)
+		}
+	}
+	if _, err := Ancestors(diamond(), "x"); err != errorNodeNotFound {
+		t.Errorf("expected `%v` got `%v`", errorNodeNotFound, err)
+	}
+}
+
+func TestReachable(t *testing.T) {
+	tt := []struct {
+		from, to string
+		expected bool
+	}{
+		{from: "a", to: "f", expected: true},
+		{from: "f", to: "a", expected: false},
+		{from: "z", to: "z", expected: true},
+		{from: "e", to: "e", expected: true},
+	}
+	for _, tc := range tt {
+		got, err := Reachable(diamond(), tc.from, tc.to)
+		if err != nil {
+			t.Errorf("expected `%v` got `%v`", nil, err)
+		}
+		if got != tc.expected {
+			t.Errorf("`%v` to `%v`: expected `%v` got `%v`", tc.from, tc.to,
+				tc.expected, got)
+		}
+	}
+	if _, err := Reachable(diamond(), "a", "x"); err != errorNodeNotFound {
+		t.Errorf("expected `%v` got `%v`", errorNodeNotFound, err)
+	}
+	t.Run("stops at destination", func(t *testing.T) {
+		g := &counting{digraph: diamond()}
+		if ok, _ := Reachable(g, "a", "b"); !ok {
+			t.Errorf("expected `%v` got `%v`", true, ok)
+		}
+		if g.calls != 1 {
+			t.Errorf("expected `%v` got `%v`", 1, g.calls)
+		}
+	})
+}
+
+// counting is a graph that counts the calls to Neighbors
+type counting struct {
+	digraph
+	calls int
+}
+
+func (c *counting) Neighbors(key string) ([]string, error) {
+	c.calls++
+	return c.digraph.Neighbors(key)
+}
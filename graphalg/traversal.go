@@ -0,0 +1,125 @@
+package graphalg
+
+import (
+	"github.com/danrl/golibby/queue"
+	"github.com/danrl/golibby/stack"
+)
+
+// visit is an item on the queue or stack of a traversal
+type visit struct {
+	key   string
+	depth int
+}
+
+// BFS traverses the graph breadth first, starting at the node identified by
+// start. The visit function is called once for every reachable node with the
+// node's distance from the start node, neighbors are visited in lexical order.
+// The traversal stops early if visit returns false.
+func BFS(g Graph, start string, visitFn func(key string, depth int) bool) error {
+	if _, err := g.Value(start); err != nil {
+		return err
+	}
+	q := queue.Queue{}
+	q.Add(visit{key: start})
+	seen := map[string]bool{start: true}
+	for q.Len() > 0 {
+		item, _ := q.Remove()
+		v := item.(visit)
+		if !visitFn(v.key, v.depth) {
+			return nil
+		}
+		neighbors, _ := g.Neighbors(v.key)
+		for _, to := range neighbors {
+			if !seen[to] {
+				seen[to] = true
+				q.Add(visit{key: to, depth: v.depth + 1})
+			}
+		}
+	}
+	return nil
+}
+
+// DFS traverses the graph depth first, starting at the node identified by
+// start. The visit function is called once for every reachable node with the
+// node's depth in the depth first search tree, neighbors are visited in
+// lexical order. The traversal stops early if visit returns false.
+func DFS(g Graph, start string, visitFn func(key string, depth int) bool) error {
+	if _, err := g.Value(start); err != nil {
+		return err
+	}
+	s := stack.Stack{}
+	s.Push(visit{key: start})
+	seen := make(map[string]bool)
+	for s.Len() > 0 {
+		item, _ := s.Pop()
+		v := item.(visit)
+		if seen[v.key] {
+			continue
+		}
+		seen[v.key] = true
+		if !visitFn(v.key, v.depth) {
+			return nil
+		}
+		// push in reverse order, so that the smallest key is popped first
+		neighbors, _ := g.Neighbors(v.key)
+		for i := len(neighbors) - 1; i >= 0; i-- {
+			if !seen[neighbors[i]] {
+				s.Push(visit{key: neighbors[i], depth: v.depth + 1})
+			}
+		}
+	}
+	return nil
+}
+
+// Distances returns the number of edges on a shortest path from the node
+// identified by from to every reachable node
+func Distances(g Graph, from string) (map[string]int, error) {
+	distances := make(map[string]int)
+	err := BFS(g, from, func(key string, depth int) bool {
+		distances[key] = depth
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return distances, nil
+}
+
+// ShortestPath returns the keys of the nodes along a path with the least
+// number of edges between two nodes, including both nodes. ErrorNoPath is
+// returned if there is no such path.
+func ShortestPath(g Graph, from, to string) ([]string, error) {
+	if _, err := g.Value(from); err != nil {
+		return nil, err
+	}
+	if _, err := g.Value(to); err != nil {
+		return nil, err
+	}
+
+	parent := map[string]string{from: from}
+	q := queue.Queue{}
+	q.Add(from)
+	for q.Len() > 0 {
+		item, _ := q.Remove()
+		key := item.(string)
+		if key == to {
+			path := []string{to}
+			for key != from {
+				key = parent[key]
+				path = append(path, key)
+			}
+			for l, r := 0, len(path)-1; l < r; l, r = l+1, r-1 {
+				path[l], path[r] = path[r], path[l]
+			}
+			return path, nil
+		}
+		neighbors, _ := g.Neighbors(key)
+		for _, next := range neighbors {
+			if _, seen := parent[next]; !seen {
+				parent[next] = key
+				q.Add(next)
+			}
+		}
+	}
+	return nil, ErrorNoPath
+}
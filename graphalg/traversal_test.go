@@ -0,0 +1,137 @@
+package graphalg
+
+import (
+	"testing"
+)
+
+// ladder returns an undirected graph shaped like a ladder with a tail
+//
+//	a - b - e - f
+//	|   |
+//	c - d
+func ladder() digraph {
+	return undirected([]string{"a", "b", "c", "d", "e", "f", "z"},
+		[2]string{"a", "b"}, [2]string{"a", "c"}, [2]string{"b", "d"},
+		[2]string{"c", "d"}, [2]string{"b", "e"}, [2]string{"e", "f"})
+}
+
+func TestBFS(t *testing.T) {
+	t.Run("undirected graph", func(t *testing.T) {
+		var keys []string
+		err := BFS(ladder(), "a", func(key string, depth int) bool {
+			keys = append(keys, key)
+			return true
+		})
+		if err != nil {
+			t.Errorf("expected `%v` got `%v`", nil, err)
+		}
+		expected := []string{"a", "b", "c", "d", "e", "f"}
+		if !equal(expected, keys) {
+			t.Errorf("expected `%v` got `%v`", expected, keys)
+		}
+	})
+	t.Run("directed graph", func(t *testing.T) {
+		g := newDigraph([]string{"a", "b", "c"}, [2]string{"b", "a"},
+			[2]string{"b", "c"})
+		var keys []string
+		BFS(g, "a", func(key string, depth int) bool {
+			keys = append(keys, key)
+			return true
+		})
+		if !equal([]string{"a"}, keys) {
+			t.Errorf("expected `%v` got `%v`", []string{"a"}, keys)
+		}
+	})
+	t.Run("unknown node", func(t *testing.T) {
+		err := BFS(ladder(), "x", func(string, int) bool { return true })
+		if err != errorNodeNotFound {
+			t.Errorf("expected `%v` got `%v`", errorNodeNotFound, err)
+		}
+	})
+}
+
+func TestDFS(t *testing.T) {
+	t.Run("undirected graph", func(t *testing.T) {
+		var keys []string
+		var depths []int
+		err := DFS(ladder(), "a", func(key string, depth int) bool {
+			keys = append(keys, key)
+			depths = append(depths, depth)
+			return true
+		})
+		if err != nil {
+			t.Errorf("expected `%v` got `%v`", nil, err)
+		}
+		expected := []string{"a", "b", "d", "c", "e", "f"}
+		if !equal(expected, keys) {
+			t.Errorf("expected `%v` got `%v`", expected, keys)
+		}
+		expectedDepths := []int{0, 1, 2, 3, 2, 3}
+		for i := range expectedDepths {
+			if depths[i] != expectedDepths[i] {
+				t.Errorf("node `%v`: expected depth `%v` got `%v`", keys[i],
+					expectedDepths[i], depths[i])
+			}
+		}
+	})
+	t.Run("unknown node", func(t *testing.T) {
+		err := DFS(ladder(), "x", func(string, int) bool { return true })
+		if err != errorNodeNotFound {
+			t.Errorf("expected `%v` got `%v`", errorNodeNotFound, err)
+		}
+	})
+}
+
+func TestDistances(t *testing.T) {
+	got, err := Distances(ladder(), "d")
+	if err != nil {
+		t.Errorf("expected `%v` got `%v`", nil, err)
+	}
+	expected := map[string]int{"a": 2, "b": 1, "c": 1, "d": 0, "e": 2, "f": 3}
+	if len(got) != len(expected) {
+		t.Errorf("expected `%v` got `%v`", expected, got)
+	}
+	for key, distance := range expected {
+		if got[key] != distance {
+			t.Errorf("node `%v`: expected `%v` got `%v`", key, distance, got[key])
+		}
+	}
+	if _, err := Distances(ladder(), "x"); err != errorNodeNotFound {
+		t.Errorf("expected `%v` got `%v`", errorNodeNotFound, err)
+	}
+}
+
+func TestShortestPath(t *testing.T) {
+	t.Run("undirected graph", func(t *testing.T) {
+		got, err := ShortestPath(ladder(), "c", "f")
+		if err != nil {
+			t.Errorf("expected `%v` got `%v`", nil, err)
+		}
+		expected := []string{"c", "a", "b", "e", "f"}
+		if !equal(expected, got) {
+			t.Errorf("expected `%v` got `%v`", expected, got)
+		}
+	})
+	t.Run("directed graph", func(t *testing.T) {
+		g := newDigraph([]string{"a", "b", "c"}, [2]string{"a", "b"},
+			[2]string{"b", "c"}, [2]string{"c", "a"})
+		got, _ := ShortestPath(g, "c", "b")
+		expected := []string{"c", "a", "b"}
+		if !equal(expected, got) {
+			t.Errorf("expected `%v` got `%v`", expected, got)
+		}
+	})
+	t.Run("no path", func(t *testing.T) {
+		if _, err := ShortestPath(ladder(), "a", "z"); err != ErrorNoPath {
+			t.Errorf("expected `%v` got `%v`", ErrorNoPath, err)
+		}
+	})
+	t.Run("unknown nodes", func(t *testing.T) {
+		if _, err := ShortestPath(ladder(), "a", "x"); err != errorNodeNotFound {
+			t.Errorf("expected `%v` got `%v`", errorNodeNotFound, err)
+		}
+		if _, err := ShortestPath(ladder(), "x", "a"); err != errorNodeNotFound {
+			t.Errorf("expected `%v` got `%v`", errorNodeNotFound, err)
+		}
+	})
+}
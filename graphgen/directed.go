@@ -0,0 +1,52 @@
+package graphgen
+
+import (
+	"math/rand"
+
+	"github.com/danrl/golibby/directedgraph"
+)
+
+// directedNodes returns a directed graph with n nodes and no edges
+func directedNodes(n int) *directedgraph.DirectedGraph {
+	g := directedgraph.New()
+	for i := 0; i < n; i++ {
+		g.NewNode(key(i), nil)
+	}
+	return g
+}
+
+// DirectedErdosRenyi returns a directed graph with n nodes in which every
+// ordered pair of distinct nodes is connected with probability p
+func DirectedErdosRenyi(n int, p float64, rng *rand.Rand) (*directedgraph.DirectedGraph, error) {
+	if n < 0 || !validProbability(p) {
+		return nil, ErrorInvalidParameter
+	}
+	g := directedNodes(n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if i != j && rng.Float64() < p {
+				g.NewEdge(key(i), key(j))
+			}
+		}
+	}
+	return g, nil
+}
+
+// RandomDAG returns a directed acyclic graph with n nodes. The nodes are put in
+// a random order and every pair of nodes is connected with probability p by an
+// edge pointing from the earlier to the later node.
+func RandomDAG(n int, p float64, rng *rand.Rand) (*directedgraph.DirectedGraph, error) {
+	if n < 0 || !validProbability(p) {
+		return nil, ErrorInvalidParameter
+	}
+	g := directedNodes(n)
+	order := rng.Perm(n)
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			if rng.Float64() < p {
+				g.NewEdge(key(order[i]), key(order[j]))
+			}
+		}
+	}
+	return g, nil
+}
@@ -0,0 +1,47 @@
+package graphgen
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestDirectedErdosRenyi(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	g, err := DirectedErdosRenyi(10, 1, rng)
+	if err != nil {
+		t.Fatalf("expected `%v` got `%v`", nil, err)
+	}
+	for _, key := range g.Nodes() {
+		if got, _ := g.OutDegree(key); got != 9 {
+			t.Errorf("node `%v`: expected out-degree `%v` got `%v`", key, 9, got)
+		}
+	}
+	if _, err := DirectedErdosRenyi(10, 2, rng); err != ErrorInvalidParameter {
+		t.Errorf("expected `%v` got `%v`", ErrorInvalidParameter, err)
+	}
+}
+
+func TestRandomDAG(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for seed := 0; seed < 10; seed++ {
+		g, err := RandomDAG(50, 0.3, rng)
+		if err != nil {
+			t.Fatalf("expected `%v` got `%v`", nil, err)
+		}
+		if g.IsCyclic() {
+			t.Errorf("expected acyclic graph")
+		}
+	}
+	g, _ := RandomDAG(10, 1, rng)
+	count := 0
+	for _, key := range g.Nodes() {
+		out, _ := g.OutDegree(key)
+		count += out
+	}
+	if count != 45 {
+		t.Errorf("expected `%v` edges got `%v`", 45, count)
+	}
+	if _, err := RandomDAG(-1, 0.5, rng); err != ErrorInvalidParameter {
+		t.Errorf("expected `%v` got `%v`", ErrorInvalidParameter, err)
+	}
+}
@@ -0,0 +1,227 @@
+// Package graphgen generates random and structured graphs for tests and
+// benchmarks. Nodes are keyed by their index, starting at "0", and have no
+// value. All random generators draw from the given source only, so the same
+// seed always yields the same graph.
+package graphgen
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+
+	"github.com/danrl/golibby/graph"
+)
+
+// ErrorInvalidParameter is returned when a generator is called with parameters
+// that do not describe a graph
+var ErrorInvalidParameter = fmt.Errorf("invalid parameter")
+
+// key returns the key of the node with index i
+func key(i int) string {
+	return strconv.Itoa(i)
+}
+
+// validProbability reports whether p is a probability
+func validProbability(p float64) bool {
+	return p >= 0 && p <= 1
+}
+
+// nodes returns an undirected graph with n nodes and no edges
+func nodes(n int) *graph.Graph {
+	g := graph.New()
+	for i := 0; i < n; i++ {
+		g.NewNode(key(i), nil)
+	}
+	return g
+}
+
+// ErdosRenyi returns a graph with n nodes in which every pair of nodes is
+// connected with probability p
+func ErdosRenyi(n int, p float64, rng *rand.Rand) (*graph.Graph, error) {
+	if n < 0 || !validProbability(p) {
+		return nil, ErrorInvalidParameter
+	}
+	g := nodes(n)
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			if rng.Float64() < p {
+				g.NewEdge(key(i), key(j))
+			}
+		}
+	}
+	return g, nil
+}
+
+// BarabasiAlbert returns a scale-free graph with n nodes grown by preferential
+// attachment. The graph starts as a complete graph of m+1 nodes, every further
+// node is connected to m distinct existing nodes chosen with probability
+// proportional to their degree.
+func BarabasiAlbert(n, m int, rng *rand.Rand) (*graph.Graph, error) {
+	if m < 1 || n <= m {
+		return nil, ErrorInvalidParameter
+	}
+	g := nodes(n)
+	// every node appears once for every edge it is part of, so that a uniform
+	// choice from targets is proportional to the degree
+	var targets []int
+	for i := 0; i <= m; i++ {
+		for j := i + 1; j <= m; j++ {
+			g.NewEdge(key(i), key(j))
+			targets = append(targets, i, j)
+		}
+	}
+	for i := m + 1; i < n; i++ {
+		chosen := make(map[int]bool, m)
+		order := make([]int, 0, m)
+		for len(order) < m {
+			j := targets[rng.Intn(len(targets))]
+			if !chosen[j] {
+				chosen[j] = true
+				order = append(order, j)
+			}
+		}
+		for _, j := range order {
+			g.NewEdge(key(i), key(j))
+			targets = append(targets, i, j)
+		}
+	}
+	return g, nil
+}
+
+// WattsStrogatz returns a small-world graph with n nodes. The graph starts as
+// a ring in which every node is connected to its k nearest neighbors, k/2 on
+// either side. Then every edge is rewired with probability beta to a random
+// node, avoiding self-loops and duplicate edges. k must be even and smaller
+// than n.
+func WattsStrogatz(n, k int, beta float64, rng *rand.Rand) (*graph.Graph, error) {
+	if n < 0 || k < 0 || k%2 != 0 || k >= n && n > 0 || !validProbability(beta) {
+		return nil, ErrorInvalidParameter
+	}
+	adj := make([]map[int]bool, n)
+	for i := range adj {
+		adj[i] = make(map[int]bool)
+	}
+	for i := 0; i < n; i++ {
+		for j := 1; j <= k/2; j++ {
+			adj[i][(i+j)%n] = true
+			adj[(i+j)%n][i] = true
+		}
+	}
+	for j := 1; j <= k/2; j++ {
+		for i := 0; i < n; i++ {
+			to := (i + j) % n
+			if rng.Float64() >= beta || len(adj[i]) >= n-1 {
+				continue
+			}
+			w := rng.Intn(n)
+			for w == i || adj[i][w] {
+				w = rng.Intn(n)
+			}
+			delete(adj[i], to)
+			delete(adj[to], i)
+			adj[i][w] = true
+			adj[w][i] = true
+		}
+	}
+	g := nodes(n)
+	for i := range adj {
+		for j := range adj[i] {
+			if i < j {
+				g.NewEdge(key(i), key(j))
+			}
+		}
+	}
+	return g, nil
+}
+
+// Grid returns a graph with rows times cols nodes arranged in a grid, in which
+// every node is connected to its horizontal and vertical neighbors. The node in
+// row r and column c has index r*cols+c.
+func Grid(rows, cols int) (*graph.Graph, error) {
+	if rows < 0 || cols < 0 {
+		return nil, ErrorInvalidParameter
+	}
+	g := nodes(rows * cols)
+	for r := 0; r < rows; r++ {
+		for c := 0; c < cols; c++ {
+			if c+1 < cols {
+				g.NewEdge(key(r*cols+c), key(r*cols+c+1))
+			}
+			if r+1 < rows {
+				g.NewEdge(key(r*cols+c), key((r+1)*cols+c))
+			}
+		}
+	}
+	return g, nil
+}
+
+// Complete returns a graph with n nodes in which every pair of nodes is
+// connected
+func Complete(n int) (*graph.Graph, error) {
+	if n < 0 {
+		return nil, ErrorInvalidParameter
+	}
+	g := nodes(n)
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			g.NewEdge(key(i), key(j))
+		}
+	}
+	return g, nil
+}
+
+// Star returns a graph with n nodes in which node "0" is connected to all other
+// nodes
+func Star(n int) (*graph.Graph, error) {
+	if n < 0 {
+		return nil, ErrorInvalidParameter
+	}
+	g := nodes(n)
+	for i := 1; i < n; i++ {
+		g.NewEdge(key(0), key(i))
+	}
+	return g, nil
+}
+
+// RandomTree returns a tree with n nodes drawn uniformly from all labeled trees
+// by decoding a random Prüfer sequence
+func RandomTree(n int, rng *rand.Rand) (*graph.Graph, error) {
+	if n < 0 {
+		return nil, ErrorInvalidParameter
+	}
+	g := nodes(n)
+	if n < 2 {
+		return g, nil
+	}
+	sequence := make([]int, n-2)
+	degree := make([]int, n)
+	for i := range degree {
+		degree[i] = 1
+	}
+	for i := range sequence {
+		sequence[i] = rng.Intn(n)
+		degree[sequence[i]]++
+	}
+	// connect every sequence entry to the smallest leaf, the leaves are found
+	// with a moving pointer in linear time
+	ptr := 0
+	for degree[ptr] != 1 {
+		ptr++
+	}
+	leaf := ptr
+	for _, v := range sequence {
+		g.NewEdge(key(leaf), key(v))
+		degree[v]--
+		if v < ptr && degree[v] == 1 {
+			leaf = v
+			continue
+		}
+		ptr++
+		for degree[ptr] != 1 {
+			ptr++
+		}
+		leaf = ptr
+	}
+	g.NewEdge(key(leaf), key(n-1))
+	return g, nil
+}
@@ -0,0 +1,227 @@
+package graphgen
+
+import (
+	"encoding/json"
+	"math/rand"
+	"testing"
+
+	"github.com/danrl/golibby/graph"
+)
+
+// countEdges returns the number of edges of an undirected graph
+func countEdges(g *graph.Graph) int {
+	count := 0
+	for _, key := range g.Nodes() {
+		edges, _ := g.Edges(key)
+		count += len(edges)
+	}
+	return count / 2
+}
+
+// maxDegree returns the largest number of neighbors of any node
+func maxDegree(g *graph.Graph) int {
+	max := 0
+	for _, key := range g.Nodes() {
+		if edges, _ := g.Edges(key); len(edges) > max {
+			max = len(edges)
+		}
+	}
+	return max
+}
+
+func TestReproducible(t *testing.T) {
+	generators := map[string]func(rng *rand.Rand) (*graph.Graph, error){
+		"erdos renyi": func(rng *rand.Rand) (*graph.Graph, error) {
+			return ErdosRenyi(30, 0.2, rng)
+		},
+		"barabasi albert": func(rng *rand.Rand) (*graph.Graph, error) {
+			return BarabasiAlbert(30, 2, rng)
+		},
+		"watts strogatz": func(rng *rand.Rand) (*graph.Graph, error) {
+			return WattsStrogatz(30, 4, 0.3, rng)
+		},
+		"random tree": func(rng *rand.Rand) (*graph.Graph, error) {
+			return RandomTree(30, rng)
+		},
+	}
+	for name, generate := range generators {
+		t.Run(name, func(t *testing.T) {
+			a, _ := generate(rand.New(rand.NewSource(42)))
+			b, _ := generate(rand.New(rand.NewSource(42)))
+			c, _ := generate(rand.New(rand.NewSource(43)))
+			ja, _ := json.Marshal(a)
+			jb, _ := json.Marshal(b)
+			jc, _ := json.Marshal(c)
+			if string(ja) != string(jb) {
+				t.Errorf("expected equal graphs for equal seeds")
+			}
+			if string(ja) == string(jc) {
+				t.Errorf("expected different graphs for different seeds")
+			}
+		})
+	}
+}
+
+func TestErdosRenyi(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	t.Run("no edges", func(t *testing.T) {
+		g, _ := ErdosRenyi(20, 0, rng)
+		if len(g.Nodes()) != 20 || countEdges(g) != 0 {
+			t.Errorf("expected `%v` nodes and `%v` edges", 20, 0)
+		}
+	})
+	t.Run("all edges", func(t *testing.T) {
+		g, _ := ErdosRenyi(20, 1, rng)
+		if got := countEdges(g); got != 190 {
+			t.Errorf("expected `%v` got `%v`", 190, got)
+		}
+	})
+	t.Run("expected density", func(t *testing.T) {
+		g, _ := ErdosRenyi(200, 0.1, rng)
+		// 1990 edges are expected, the standard deviation is about 42
+		if got := countEdges(g); got < 1800 || got > 2200 {
+			t.Errorf("expected about `%v` edges got `%v`", 1990, got)
+		}
+	})
+	t.Run("invalid parameters", func(t *testing.T) {
+		for _, p := range []float64{-0.1, 1.1} {
+			if _, err := ErdosRenyi(5, p, rng); err != ErrorInvalidParameter {
+				t.Errorf("expected `%v` got `%v`", ErrorInvalidParameter, err)
+			}
+		}
+		if _, err := ErdosRenyi(-1, 0.5, rng); err != ErrorInvalidParameter {
+			t.Errorf("expected `%v` got `%v`", ErrorInvalidParameter, err)
+		}
+	})
+}
+
+func TestBarabasiAlbert(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	t.Run("edge count", func(t *testing.T) {
+		g, err := BarabasiAlbert(500, 3, rng)
+		if err != nil {
+			t.Fatalf("expected `%v` got `%v`", nil, err)
+		}
+		if got := countEdges(g); got != 6+496*3 {
+			t.Errorf("expected `%v` got `%v`", 6+496*3, got)
+		}
+		if got := len(g.ConnectedComponents()); got != 1 {
+			t.Errorf("expected `%v` component got `%v`", 1, got)
+		}
+		// preferential attachment produces hubs
+		if got := maxDegree(g); got < 30 {
+			t.Errorf("expected hub with degree of at least `%v` got `%v`", 30, got)
+		}
+	})
+	t.Run("invalid parameters", func(t *testing.T) {
+		for _, nm := range [][2]int{{5, 0}, {3, 3}} {
+			if _, err := BarabasiAlbert(nm[0], nm[1], rng); err != ErrorInvalidParameter {
+				t.Errorf("expected `%v` got `%v`", ErrorInvalidParameter, err)
+			}
+		}
+	})
+}
+
+func TestWattsStrogatz(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	t.Run("ring lattice", func(t *testing.T) {
+		g, _ := WattsStrogatz(10, 4, 0, rng)
+		if got := countEdges(g); got != 20 {
+			t.Errorf("expected `%v` got `%v`", 20, got)
+		}
+		for _, key := range g.Nodes() {
+			if edges, _ := g.Edges(key); len(edges) != 4 {
+				t.Errorf("node `%v`: expected degree `%v` got `%v`", key, 4,
+					len(edges))
+			}
+		}
+		if w, err := g.Weight("9", "1"); err != nil || w != 1 {
+			t.Errorf("expected edge `%v`-`%v`", "9", "1")
+		}
+	})
+	t.Run("rewired", func(t *testing.T) {
+		g, _ := WattsStrogatz(100, 6, 0.5, rng)
+		if got := countEdges(g); got != 300 {
+			t.Errorf("expected `%v` got `%v`", 300, got)
+		}
+		lattice, _ := WattsStrogatz(100, 6, 0, rng)
+		if maxDegree(g) == maxDegree(lattice) {
+			t.Errorf("expected rewired edges")
+		}
+	})
+	t.Run("invalid parameters", func(t *testing.T) {
+		for _, nk := range [][2]int{{10, 3}, {4, 4}, {10, -2}} {
+			if _, err := WattsStrogatz(nk[0], nk[1], 0.5, rng); err != ErrorInvalidParameter {
+				t.Errorf("expected `%v` got `%v`", ErrorInvalidParameter, err)
+			}
+		}
+	})
+}
+
+func TestStructured(t *testing.T) {
+	tt := []struct {
+		name     string
+		generate func() (*graph.Graph, error)
+		nodes    int
+		edges    int
+	}{
+		{name: "grid", generate: func() (*graph.Graph, error) { return Grid(3, 4) },
+			nodes: 12, edges: 17},
+		{name: "empty grid", generate: func() (*graph.Graph, error) { return Grid(0, 4) },
+			nodes: 0, edges: 0},
+		{name: "complete", generate: func() (*graph.Graph, error) { return Complete(6) },
+			nodes: 6, edges: 15},
+		{name: "star", generate: func() (*graph.Graph, error) { return Star(6) },
+			nodes: 6, edges: 5},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			g, err := tc.generate()
+			if err != nil {
+				t.Fatalf("expected `%v` got `%v`", nil, err)
+			}
+			if got := len(g.Nodes()); got != tc.nodes {
+				t.Errorf("expected `%v` nodes got `%v`", tc.nodes, got)
+			}
+			if got := countEdges(g); got != tc.edges {
+				t.Errorf("expected `%v` edges got `%v`", tc.edges, got)
+			}
+		})
+	}
+	t.Run("invalid parameters", func(t *testing.T) {
+		if _, err := Grid(-1, 2); err != ErrorInvalidParameter {
+			t.Errorf("expected `%v` got `%v`", ErrorInvalidParameter, err)
+		}
+		if _, err := Complete(-1); err != ErrorInvalidParameter {
+			t.Errorf("expected `%v` got `%v`", ErrorInvalidParameter, err)
+		}
+		if _, err := Star(-1); err != ErrorInvalidParameter {
+			t.Errorf("expected `%v` got `%v`", ErrorInvalidParameter, err)
+		}
+	})
+}
+
+func TestRandomTree(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, n := range []int{0, 1, 2, 3, 10, 1000} {
+		g, err := RandomTree(n, rng)
+		if err != nil {
+			t.Fatalf("expected `%v` got `%v`", nil, err)
+		}
+		if got := len(g.Nodes()); got != n {
+			t.Errorf("expected `%v` nodes got `%v`", n, got)
+		}
+		if n == 0 {
+			continue
+		}
+		if got := countEdges(g); got != n-1 {
+			t.Errorf("expected `%v` edges got `%v`", n-1, got)
+		}
+		if got := len(g.ConnectedComponents()); got != 1 {
+			t.Errorf("expected `%v` component got `%v`", 1, got)
+		}
+	}
+	if _, err := RandomTree(-1, rng); err != ErrorInvalidParameter {
+		t.Errorf("expected `%v` got `%v`", ErrorInvalidParameter, err)
+	}
+}
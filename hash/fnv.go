@@ -0,0 +1,19 @@
+package hash
+
+const (
+	fnv64Offset = 0xcbf29ce484222325
+	fnv64Prime  = 0x100000001b3
+)
+
+// FNV1a64 implements the 64 bit FNV-1a hash function. The seed is mixed into
+// the offset basis, a seed of zero yields the standard FNV-1a hash. Seeding
+// makes bucket placement unpredictable across hash tables, but unlike
+// SipHash24 it does not prevent crafted collisions.
+func FNV1a64(seed uint64, x []byte) uint64 {
+	h := uint64(fnv64Offset) ^ seed
+	for i := range x {
+		h ^= uint64(x[i])
+		h *= fnv64Prime
+	}
+	return h
+}
@@ -0,0 +1,14 @@
+package hash
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFNV1a64(t *testing.T) {
+	assert.Equal(t, uint64(0xcbf29ce484222325), FNV1a64(0, []byte{}))
+	assert.Equal(t, uint64(0xaf63dc4c8601ec8c), FNV1a64(0, []byte{'a'}))
+	assert.Equal(t, uint64(0x85944171f73967e8), FNV1a64(0, []byte("foobar")))
+	assert.NotEqual(t, FNV1a64(0, []byte("foobar")), FNV1a64(1, []byte("foobar")))
+}
@@ -43,3 +43,20 @@ func Pearson16(x []byte) uint16 {
 	}
 	return (uint16(h1) << 8) | uint16(h2)
 }
+
+// Pearson64 implements a 64 bit version of the Pearson hash. Every byte of the
+// result is a Pearson hash of the input with a different first byte.
+func Pearson64(x []byte) uint64 {
+	if len(x) == 0 {
+		return 0
+	}
+	var h uint64
+	for j := 0; j < 8; j++ {
+		b := pearsonTable[x[0]+uint8(j)]
+		for i := 1; i < len(x); i++ {
+			b = pearsonTable[b^x[i]]
+		}
+		h = h<<8 | uint64(b)
+	}
+	return h
+}
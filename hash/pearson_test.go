@@ -17,3 +17,12 @@ func TestPearson16(t *testing.T) {
 	assert.Equal(t, uint16(0x1bc5), Pearson16([]byte{'f', 'o', 'o'}))
 	assert.Equal(t, uint16(0x4a61), Pearson16([]byte{'b', 'a', 'r'}))
 }
+
+func TestPearson64(t *testing.T) {
+	assert.Equal(t, uint64(0x0), Pearson64([]byte{}))
+	// the first byte matches the 8 bit version
+	assert.Equal(t, uint8(0x1b), uint8(Pearson64([]byte{'f', 'o', 'o'})>>56))
+	assert.Equal(t, uint8(0x4a), uint8(Pearson64([]byte{'b', 'a', 'r'})>>56))
+	assert.NotEqual(t, Pearson64([]byte{'f', 'o', 'o'}),
+		Pearson64([]byte{'b', 'a', 'r'}))
+}
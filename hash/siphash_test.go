@@ -0,0 +1,26 @@
+package hash
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSipHash24(t *testing.T) {
+	// test vectors from the reference implementation use the key 00 01 ... 0f
+	// and the messages 00, 00 01, 00 01 02 and so on
+	var key [16]byte
+	for i := range key {
+		key[i] = byte(i)
+	}
+	message := make([]byte, 16)
+	for i := range message {
+		message[i] = byte(i)
+	}
+	assert.Equal(t, uint64(0x726fdb47dd0e0e31), SipHash24(key, message[:0]))
+	assert.Equal(t, uint64(0x74f839c593dc67fd), SipHash24(key, message[:1]))
+	assert.Equal(t, uint64(0x93f5f5799a932462), SipHash24(key, message[:8]))
+	assert.Equal(t, uint64(0xa129ca6149be45e5), SipHash24(key, message[:15]))
+	assert.NotEqual(t, SipHash24(key, []byte("foo")),
+		SipHash24([16]byte{}, []byte("foo")))
+}
@@ -0,0 +1,45 @@
+package hashmap
+
+import (
+	"crypto/rand"
+
+	"github.com/danrl/golibby/hash"
+)
+
+// HashFunc computes the hash sum of a key. Items are placed in buckets by the
+// low bits of their hash sum.
+type HashFunc func(key string) uint64
+
+// SipHash returns a HashFunc computing SipHash-2-4 with the given key. Keys
+// chosen by an attacker who does not know the key cannot be forced into a
+// single bucket.
+func SipHash(key [16]byte) HashFunc {
+	return func(s string) uint64 {
+		return hash.SipHash24(key, []byte(s))
+	}
+}
+
+// FNV1a returns a HashFunc computing the FNV-1a hash with the given seed. It
+// is faster than SipHash, but does not resist crafted collisions.
+func FNV1a(seed uint64) HashFunc {
+	return func(s string) uint64 {
+		return hash.FNV1a64(seed, []byte(s))
+	}
+}
+
+// Pearson returns a HashFunc computing the unseeded 64 bit Pearson hash
+func Pearson() HashFunc {
+	return func(s string) uint64 {
+		return hash.Pearson64([]byte(s))
+	}
+}
+
+// randomSipHash returns a HashFunc computing SipHash-2-4 with a random key
+func randomSipHash() HashFunc {
+	var key [16]byte
+	if _, err := rand.Read(key[:]); err != nil {
+		// without a random key the hash map would be open to HashDoS
+		panic("hashmap: reading random key: " + err.Error())
+	}
+	return SipHash(key)
+}
@@ -3,68 +3,232 @@ package hashmap
 import (
 	"fmt"
 	"sync"
+)
 
-	"github.com/danrl/golibby/hash"
+const (
+	// MinBuckets is the number of buckets a hash map starts with. It never
+	// shrinks below this size.
+	MinBuckets = 8
+	// MaxLoadFactor is the average number of items per bucket above which the
+	// number of buckets is doubled
+	MaxLoadFactor = 4.0
+	// MinLoadFactor is the average number of items per bucket below which the
+	// number of buckets is halved
+	MinLoadFactor = 1.0
+	// migrationStep is the number of buckets moved from the old table to the
+	// new table per modification while resizing
+	migrationStep = 4
 )
 
-// HashMap holds a concurrency-safe hashmap implementation
+// HashMap holds a concurrency-safe hashmap implementation. The zero value is
+// an empty hash map using SipHash-2-4 with a random key, that allocates no
+// buckets until the first item is added.
+// The number of buckets grows and shrinks with the number of items. Items are
+// moved to a resized table incrementally with every modification, so that no
+// single modification has to rehash all items.
 type HashMap struct {
-	lock sync.RWMutex
-	data [1 << 16][]item
+	lock    sync.RWMutex
+	hash    HashFunc
+	current *table
+	// old holds the table items are migrated from while resizing, buckets
+	// below migrated have been moved to the current table already
+	old      *table
+	migrated int
+	count    int
 }
 
 type item struct {
 	key   string
 	value interface{}
+	hash  uint64
 }
 
 // ErrorNotFound indicates that the requested item does not exist
 var ErrorNotFound = fmt.Errorf("not found")
 
+// Options configures a hash map
+type Options struct {
+	// Hash is the hash function used to place items in buckets. If nil,
+	// SipHash-2-4 with a random key is used.
+	Hash HashFunc
+}
+
+// New returns an empty hash map
+func New(opts Options) *HashMap {
+	h := &HashMap{hash: opts.Hash}
+	if h.hash == nil {
+		h.hash = randomSipHash()
+	}
+	return h
+}
+
+// locate returns the table and the index of the bucket that holds a key with
+// the given hash
+func (h *HashMap) locate(sum uint64) (*table, int) {
+	if h.old != nil {
+		if i := h.old.index(sum); i >= h.migrated {
+			return h.old, i
+		}
+	}
+	return h.current, h.current.index(sum)
+}
+
+// migrate moves up to n buckets from the old table to the current table
+func (h *HashMap) migrate(n int) {
+	for ; h.old != nil && n > 0; n-- {
+		h.old.move(h.migrated, h.current)
+		h.migrated++
+		if h.migrated == len(h.old.buckets) {
+			h.old = nil
+			h.migrated = 0
+		}
+	}
+}
+
+// resize starts migrating all items to a table with the given number of
+// buckets. A migration still in progress is completed first.
+func (h *HashMap) resize(size int) {
+	if h.old != nil {
+		h.migrate(len(h.old.buckets))
+	}
+	h.old = h.current
+	h.current = newTable(size)
+}
+
+// upsert inserts or updates the value for a key with the given hash sum
+// without acquiring the lock. It returns true if the key has been inserted.
+func (h *HashMap) upsert(sum uint64, key string, value interface{}) bool {
+	if h.current == nil {
+		h.current = newTable(MinBuckets)
+	}
+	h.migrate(migrationStep)
+	t, i := h.locate(sum)
+	if j := t.find(i, key); j >= 0 {
+		t.buckets[i][j].value = value
+		return false
+	}
+	t.buckets[i] = append(t.buckets[i], item{
+		key:   key,
+		value: value,
+		hash:  sum,
+	})
+	h.count++
+	if float64(h.count) > MaxLoadFactor*float64(len(h.current.buckets)) {
+		h.resize(2 * len(h.current.buckets))
+	}
+	return true
+}
+
+// delete removes the value for a key with the given hash sum without
+// acquiring the lock. It returns false if the key does not exist.
+func (h *HashMap) delete(sum uint64, key string) bool {
+	if h.current == nil {
+		return false
+	}
+	h.migrate(migrationStep)
+	t, i := h.locate(sum)
+	j := t.find(i, key)
+	if j < 0 {
+		return false
+	}
+	t.remove(i, j)
+	h.count--
+	size := len(h.current.buckets)
+	if size > MinBuckets && float64(h.count) < MinLoadFactor*float64(size) {
+		h.resize(size / 2)
+	}
+	return true
+}
+
+// value returns the value for a key with the given hash sum without acquiring
+// the lock
+func (h *HashMap) value(sum uint64, key string) (interface{}, bool) {
+	if h.current == nil {
+		return nil, false
+	}
+	t, i := h.locate(sum)
+	if j := t.find(i, key); j >= 0 {
+		return t.buckets[i][j].value, true
+	}
+	return nil, false
+}
+
+// items appends all items to a slice without acquiring the lock
+func (h *HashMap) items(items []item) []item {
+	for _, t := range []*table{h.old, h.current} {
+		if t == nil {
+			continue
+		}
+		for _, b := range t.buckets {
+			items = append(items, b...)
+		}
+	}
+	return items
+}
+
 // Upsert inserts or updates the value for a given key
 func (h *HashMap) Upsert(key string, value interface{}) {
 	h.lock.Lock()
 	defer h.lock.Unlock()
-	offset := hash.Pearson16([]byte(key))
-	updated := false
-	for i := range h.data[offset] {
-		if h.data[offset][i].key == key {
-			h.data[offset][i].value = value
-			updated = true
-			break
-		}
-	}
-	if !updated {
-		h.data[offset] = append(h.data[offset], item{
-			key:   key,
-			value: value,
-		})
+
+	if h.hash == nil {
+		h.hash = randomSipHash()
 	}
+	h.upsert(h.hash(key), key, value)
 }
 
 // Delete removes the value for a given key from the hash map
 func (h *HashMap) Delete(key string) error {
 	h.lock.Lock()
 	defer h.lock.Unlock()
-	offset := hash.Pearson16([]byte(key))
-	for i := range h.data[offset] {
-		if h.data[offset][i].key == key {
-			h.data[offset] = append(h.data[offset][:i], h.data[offset][i+1:]...)
-			return nil
-		}
+
+	if h.current == nil || !h.delete(h.hash(key), key) {
+		return ErrorNotFound
 	}
-	return ErrorNotFound
+	return nil
 }
 
 // Value returns the value for a given key in the hash map
 func (h *HashMap) Value(key string) (interface{}, error) {
 	h.lock.RLock()
 	defer h.lock.RUnlock()
-	offset := hash.Pearson16([]byte(key))
-	for i := range h.data[offset] {
-		if h.data[offset][i].key == key {
-			return h.data[offset][i].value, nil
-		}
+
+	if h.current == nil {
+		return nil, ErrorNotFound
+	}
+	if value, ok := h.value(h.hash(key), key); ok {
+		return value, nil
 	}
 	return nil, ErrorNotFound
 }
+
+// Len returns the number of items in the hash map
+func (h *HashMap) Len() int {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+
+	return h.count
+}
+
+// Cap returns the number of buckets of the hash map. While resizing, it is the
+// number of buckets of the new table.
+func (h *HashMap) Cap() int {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+
+	if h.current == nil {
+		return 0
+	}
+	return len(h.current.buckets)
+}
+
+// LoadFactor returns the average number of items per bucket
+func (h *HashMap) LoadFactor() float64 {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+
+	if h.current == nil {
+		return 0
+	}
+	return float64(h.count) / float64(len(h.current.buckets))
+}
@@ -1,37 +1,75 @@
 package hashmap
 
 import (
+	"fmt"
+	"math"
 	"testing"
 
+	"github.com/danrl/golibby/hash"
 	"github.com/stretchr/testify/assert"
 )
 
+// fixed is the hash function of hash maps with predictable bucket placement
+var fixed = FNV1a(0)
+
+// collisions returns n keys, starting with the given key, that share a bucket
+// in a table with MinBuckets buckets
+func collisions(key string, n int) []string {
+	t := newTable(MinBuckets)
+	keys := []string{key}
+	for i := 0; len(keys) < n; i++ {
+		candidate := fmt.Sprintf("key-%d", i)
+		if t.index(fixed(candidate)) == t.index(fixed(key)) {
+			keys = append(keys, candidate)
+		}
+	}
+	return keys
+}
+
+// bucket returns the bucket holding a key
+func bucket(hm *HashMap, key string) []item {
+	t, i := hm.locate(hm.hash(key))
+	return t.buckets[i]
+}
+
 func TestUpsert(t *testing.T) {
 	{
 		hm := HashMap{}
-		assert.Equal(t, 1<<16, len(hm.data))
+		assert.Nil(t, hm.current)
+		assert.Equal(t, 0, hm.Len())
+		assert.Equal(t, 0, hm.Cap())
+
+		// a random key is picked for the zero value on first use
+		hm.Upsert("foo", "bar")
+		assert.NotNil(t, hm.hash)
+		assert.NotEqual(t, New(Options{}).hash("foo"), hm.hash("foo"))
 	}
 	{
-		hm := HashMap{}
+		hm := New(Options{Hash: fixed})
+		keys := collisions("foo", 2)
 
 		hm.Upsert("foo", "bar")
-		assert.Equal(t, 1, len(hm.data[0x1bc5]))
-		assert.Equal(t, "foo", hm.data[0x1bc5][0].key)
-		assert.Equal(t, "bar", hm.data[0x1bc5][0].value)
+		assert.Equal(t, MinBuckets, hm.Cap())
+		assert.Equal(t, 1, len(bucket(hm, "foo")))
+		assert.Equal(t, "foo", bucket(hm, "foo")[0].key)
+		assert.Equal(t, "bar", bucket(hm, "foo")[0].value)
 
 		hm.Upsert("foo", "updated")
-		assert.Equal(t, 1, len(hm.data[0x1bc5]))
-		assert.Equal(t, "foo", hm.data[0x1bc5][0].key)
-		assert.Equal(t, "updated", hm.data[0x1bc5][0].value)
-
-		hm.Upsert("Zero-byte", "collision")
-		assert.Equal(t, 2, len(hm.data[0x1bc5]))
-		assert.Equal(t, "Zero-byte", hm.data[0x1bc5][1].key)
-		assert.Equal(t, "collision", hm.data[0x1bc5][1].value)
+		assert.Equal(t, 1, hm.Len())
+		assert.Equal(t, 1, len(bucket(hm, "foo")))
+		assert.Equal(t, "foo", bucket(hm, "foo")[0].key)
+		assert.Equal(t, "updated", bucket(hm, "foo")[0].value)
+
+		hm.Upsert(keys[1], "collision")
+		assert.Equal(t, 2, hm.Len())
+		assert.Equal(t, 2, len(bucket(hm, "foo")))
+		assert.Equal(t, keys[1], bucket(hm, "foo")[1].key)
+		assert.Equal(t, "collision", bucket(hm, "foo")[1].value)
 	}
 }
 
 func TestDelete(t *testing.T) {
+	keys := collisions("foo", 3)
 	{
 		hm := HashMap{}
 
@@ -40,60 +78,31 @@ func TestDelete(t *testing.T) {
 	}
 	// delete single
 	{
-		hm := HashMap{}
-
-		hm.Upsert("foo", "bar")
-		err := hm.Delete("foo")
-		assert.Equal(t, nil, err)
-		assert.Equal(t, 0, len(hm.data[0x1bc5]))
-	}
-	// delete first
-	{
-		hm := HashMap{}
+		hm := New(Options{Hash: fixed})
 
 		hm.Upsert("foo", "bar")
-		hm.Upsert("Zero-byte", "collision")
-		hm.Upsert("Water of hydration", "H2O")
-
 		err := hm.Delete("foo")
 		assert.Equal(t, nil, err)
-		assert.Equal(t, 2, len(hm.data[0x1bc5]))
-		assert.Equal(t, "Zero-byte", hm.data[0x1bc5][0].key)
-		assert.Equal(t, "collision", hm.data[0x1bc5][0].value)
-		assert.Equal(t, "Water of hydration", hm.data[0x1bc5][1].key)
-		assert.Equal(t, "H2O", hm.data[0x1bc5][1].value)
+		assert.Equal(t, 0, len(bucket(hm, "foo")))
+		assert.Equal(t, 0, hm.Len())
+		assert.Equal(t, ErrorNotFound, hm.Delete("foo"))
 	}
-	// delete middle
-	{
-		hm := HashMap{}
-
-		hm.Upsert("foo", "bar")
-		hm.Upsert("Zero-byte", "collision")
-		hm.Upsert("Water of hydration", "H2O")
-
-		err := hm.Delete("Zero-byte")
+	// delete first, middle and last
+	for i := range keys {
+		hm := New(Options{Hash: fixed})
+		for _, key := range keys {
+			hm.Upsert(key, key+"-value")
+		}
+
+		err := hm.Delete(keys[i])
 		assert.Equal(t, nil, err)
-		assert.Equal(t, 2, len(hm.data[0x1bc5]))
-		assert.Equal(t, "foo", hm.data[0x1bc5][0].key)
-		assert.Equal(t, "bar", hm.data[0x1bc5][0].value)
-		assert.Equal(t, "Water of hydration", hm.data[0x1bc5][1].key)
-		assert.Equal(t, "H2O", hm.data[0x1bc5][1].value)
-	}
-	// delete last
-	{
-		hm := HashMap{}
-
-		hm.Upsert("foo", "bar")
-		hm.Upsert("Zero-byte", "collision")
-		hm.Upsert("Water of hydration", "H2O")
-
-		err := hm.Delete("Water of hydration")
-		assert.Equal(t, nil, err)
-		assert.Equal(t, 2, len(hm.data[0x1bc5]))
-		assert.Equal(t, "foo", hm.data[0x1bc5][0].key)
-		assert.Equal(t, "bar", hm.data[0x1bc5][0].value)
-		assert.Equal(t, "Zero-byte", hm.data[0x1bc5][1].key)
-		assert.Equal(t, "collision", hm.data[0x1bc5][1].value)
+		remaining := append(append([]string{}, keys[:i]...), keys[i+1:]...)
+		b := bucket(hm, "foo")
+		assert.Equal(t, len(remaining), len(b))
+		for j, key := range remaining {
+			assert.Equal(t, key, b[j].key)
+			assert.Equal(t, key+"-value", b[j].value)
+		}
 	}
 }
 
@@ -105,16 +114,103 @@ func TestValue(t *testing.T) {
 		assert.Equal(t, ErrorNotFound, err)
 	}
 	{
-		hm := HashMap{}
+		hm := New(Options{Hash: fixed})
+		keys := collisions("foo", 2)
 
 		hm.Upsert("foo", "bar")
 		value, err := hm.Value("foo")
 		assert.Equal(t, "bar", value)
 		assert.Equal(t, nil, err)
 
-		hm.Upsert("Zero-byte", "collision")
-		value, err = hm.Value("Zero-byte")
+		hm.Upsert(keys[1], "collision")
+		value, err = hm.Value(keys[1])
 		assert.Equal(t, "collision", value)
 		assert.Equal(t, nil, err)
 	}
 }
+
+func TestResize(t *testing.T) {
+	const n = 10000
+	hm := HashMap{}
+	migrating := false
+	for i := 0; i < n; i++ {
+		hm.Upsert(fmt.Sprint(i), i)
+		assert.True(t, hm.LoadFactor() <= MaxLoadFactor)
+		if hm.old != nil && !migrating {
+			// all items can be found while they are being migrated
+			migrating = true
+			for j := 0; j <= i; j++ {
+				value, err := hm.Value(fmt.Sprint(j))
+				assert.Equal(t, nil, err)
+				assert.Equal(t, j, value)
+			}
+		}
+	}
+	assert.True(t, migrating)
+	assert.Equal(t, n, hm.Len())
+	assert.True(t, hm.Cap() >= n/MaxLoadFactor)
+	for i := 0; i < n; i++ {
+		value, err := hm.Value(fmt.Sprint(i))
+		assert.Equal(t, nil, err)
+		assert.Equal(t, i, value)
+	}
+
+	for i := 10; i < n; i++ {
+		assert.Equal(t, nil, hm.Delete(fmt.Sprint(i)))
+		assert.True(t, hm.Cap() == MinBuckets || hm.LoadFactor() >= MinLoadFactor)
+	}
+	assert.Equal(t, 10, hm.Len())
+	assert.Equal(t, MinBuckets, hm.Cap())
+	for i := 0; i < n; i++ {
+		value, err := hm.Value(fmt.Sprint(i))
+		if i < 10 {
+			assert.Equal(t, nil, err)
+			assert.Equal(t, i, value)
+		} else {
+			assert.Equal(t, ErrorNotFound, err)
+		}
+	}
+}
+
+// BenchmarkDistribution hashes sequential keys and reports how evenly they
+// are spread across buckets. Perfectly uniform hashing yields a standard
+// deviation of about 4 items per bucket.
+func BenchmarkDistribution(b *testing.B) {
+	const buckets = 1 << 10
+	keys := make([]string, 16*buckets)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("key-%d", i)
+	}
+	hashes := []struct {
+		name string
+		hash HashFunc
+	}{
+		{name: "SipHash", hash: SipHash([16]byte{1, 2, 3})},
+		{name: "FNV1a", hash: FNV1a(0)},
+		{name: "Pearson", hash: Pearson()},
+		{name: "Pearson16", hash: func(key string) uint64 {
+			return uint64(hash.Pearson16([]byte(key)))
+		}},
+	}
+	for _, tc := range hashes {
+		b.Run(tc.name, func(b *testing.B) {
+			counts := make([]float64, buckets)
+			for _, key := range keys {
+				counts[tc.hash(key)&(buckets-1)]++
+			}
+			mean := float64(len(keys)) / buckets
+			largest, variance := 0.0, 0.0
+			for _, c := range counts {
+				largest = math.Max(largest, c)
+				variance += (c - mean) * (c - mean) / buckets
+			}
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				tc.hash(keys[i%len(keys)])
+			}
+			b.ReportMetric(largest, "max/bucket")
+			b.ReportMetric(math.Sqrt(variance), "stddev/bucket")
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package hashmap
+
+import (
+	"sync"
+	"sync/atomic"
+	"unsafe"
+)
+
+const (
+	// DefaultShards is the number of shards of a sharded hash map if no shard
+	// count is given
+	DefaultShards = 32
+	// cacheLine is the assumed size of a CPU cache line in bytes
+	cacheLine = 64
+)
+
+// shard is a HashMap padded to a multiple of the cache line size, so that
+// the locks of neighboring shards do not share a cache line. The padding comes
+// first, since a trailing zero size field would be padded itself.
+type shard struct {
+	_ [(cacheLine - unsafe.Sizeof(HashMap{})%cacheLine) % cacheLine]byte
+	HashMap
+}
+
+// Sharded holds a concurrency-safe hashmap implementation that splits its
+// items into shards by hash sum. Every shard is a HashMap with a lock of its
+// own, so that operations on different shards do not contend. The zero value
+// is an empty hash map with DefaultShards shards using SipHash-2-4 with a
+// random key.
+type Sharded struct {
+	// count is accessed atomically and comes first to be 64 bit aligned. It
+	// is padded to a cache line, so that updating it does not slow down
+	// reading the fields below.
+	count  int64
+	_      [cacheLine - 8]byte
+	once   sync.Once
+	hash   HashFunc
+	shards []shard
+}
+
+// NewSharded returns an empty sharded hash map with the given number of
+// shards. Values smaller than 1 select DefaultShards. All shards share the
+// hash function given in the options.
+func NewSharded(shards int, opts Options) *Sharded {
+	s := &Sharded{hash: opts.Hash}
+	s.once.Do(func() { s.setup(shards) })
+	return s
+}
+
+// setup creates the shards, it is called exactly once
+func (s *Sharded) setup(shards int) {
+	if shards < 1 {
+		shards = DefaultShards
+	}
+	if s.hash == nil {
+		s.hash = randomSipHash()
+	}
+	s.shards = make([]shard, shards)
+	for i := range s.shards {
+		s.shards[i].hash = s.hash
+	}
+}
+
+// initialize sets up the shards of a zero value hash map
+func (s *Sharded) initialize() {
+	s.once.Do(func() { s.setup(DefaultShards) })
+}
+
+// shard returns the shard for a hash sum. Shards are picked by the high bits
+// of the hash sum, buckets within a shard by the low bits.
+func (s *Sharded) shard(sum uint64) *HashMap {
+	return &s.shards[(sum>>32)*uint64(len(s.shards))>>32].HashMap
+}
+
+// Upsert inserts or updates the value for a given key
+func (s *Sharded) Upsert(key string, value interface{}) {
+	s.initialize()
+	sum := s.hash(key)
+	h := s.shard(sum)
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if h.upsert(sum, key, value) {
+		atomic.AddInt64(&s.count, 1)
+	}
+}
+
+// Delete removes the value for a given key from the hash map
+func (s *Sharded) Delete(key string) error {
+	s.initialize()
+	sum := s.hash(key)
+	h := s.shard(sum)
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if !h.delete(sum, key) {
+		return ErrorNotFound
+	}
+	atomic.AddInt64(&s.count, -1)
+	return nil
+}
+
+// Value returns the value for a given key in the hash map
+func (s *Sharded) Value(key string) (interface{}, error) {
+	s.initialize()
+	sum := s.hash(key)
+	h := s.shard(sum)
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+
+	if value, ok := h.value(sum, key); ok {
+		return value, nil
+	}
+	return nil, ErrorNotFound
+}
+
+// Len returns the number of items in the hash map without acquiring any lock
+func (s *Sharded) Len() int {
+	return int(atomic.LoadInt64(&s.count))
+}
+
+// Shards returns the number of shards
+func (s *Sharded) Shards() int {
+	s.initialize()
+	return len(s.shards)
+}
+
+// Range calls fn for every item in the hash map, in no particular order,
+// until fn returns false. The items of each shard are copied while holding
+// its lock, so fn sees every shard as it was at one point in time, but
+// different shards at different points in time. The hash map may be modified
+// from within fn.
+func (s *Sharded) Range(fn func(key string, value interface{}) bool) {
+	s.initialize()
+	var items []item
+	for i := range s.shards {
+		h := &s.shards[i].HashMap
+		h.lock.RLock()
+		items = h.items(items[:0])
+		h.lock.RUnlock()
+
+		for _, it := range items {
+			if !fn(it.key, it.value) {
+				return
+			}
+		}
+	}
+}
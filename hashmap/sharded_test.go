@@ -0,0 +1,185 @@
+package hashmap
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSharded(t *testing.T) {
+	assert.Equal(t, DefaultShards, NewSharded(0, Options{}).Shards())
+	assert.Equal(t, 3, NewSharded(3, Options{}).Shards())
+
+	s := NewSharded(3, Options{Hash: fixed})
+	for i := range s.shards {
+		assert.Equal(t, fixed("foo"), s.shards[i].hash("foo"))
+	}
+}
+
+func TestShardedZeroValue(t *testing.T) {
+	var s Sharded
+	assert.Equal(t, 0, s.Len())
+	assert.Equal(t, DefaultShards, s.Shards())
+	s.Upsert("foo", "bar")
+	value, err := s.Value("foo")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "bar", value)
+	assert.Equal(t, 1, s.Len())
+	assert.Equal(t, nil, s.Delete("foo"))
+}
+
+func TestShardPadding(t *testing.T) {
+	assert.Equal(t, uintptr(0), unsafe.Sizeof(shard{})%cacheLine)
+	var s Sharded
+	assert.Equal(t, uintptr(cacheLine), unsafe.Offsetof(s.once))
+}
+
+func TestSharded(t *testing.T) {
+	s := NewSharded(5, Options{})
+	_, err := s.Value("foo")
+	assert.Equal(t, ErrorNotFound, err)
+	assert.Equal(t, ErrorNotFound, s.Delete("foo"))
+
+	s.Upsert("foo", "bar")
+	s.Upsert("foo", "updated")
+	assert.Equal(t, 1, s.Len())
+	value, err := s.Value("foo")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "updated", value)
+
+	for i := 0; i < 1000; i++ {
+		s.Upsert(fmt.Sprint(i), i)
+	}
+	assert.Equal(t, 1001, s.Len())
+	for i := range s.shards {
+		// every shard holds a share of the items
+		assert.True(t, s.shards[i].Len() > 100)
+	}
+	for i := 0; i < 1000; i++ {
+		value, err := s.Value(fmt.Sprint(i))
+		assert.Equal(t, nil, err)
+		assert.Equal(t, i, value)
+	}
+
+	assert.Equal(t, nil, s.Delete("foo"))
+	assert.Equal(t, ErrorNotFound, s.Delete("foo"))
+	assert.Equal(t, 1000, s.Len())
+}
+
+func TestShardedConcurrency(t *testing.T) {
+	const workers, n = 8, 1000
+	s := NewSharded(4, Options{})
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < n; i++ {
+				key := fmt.Sprintf("%d-%d", w, i)
+				s.Upsert(key, i)
+				if i%2 == 0 {
+					s.Delete(key)
+				}
+				s.Len()
+			}
+		}(w)
+	}
+	wg.Wait()
+	assert.Equal(t, workers*n/2, s.Len())
+}
+
+func TestShardedRange(t *testing.T) {
+	s := NewSharded(4, Options{})
+	for i := 0; i < 100; i++ {
+		s.Upsert(fmt.Sprint(i), i)
+	}
+
+	seen := make(map[string]interface{})
+	s.Range(func(key string, value interface{}) bool {
+		seen[key] = value
+		return true
+	})
+	assert.Equal(t, 100, len(seen))
+	for i := 0; i < 100; i++ {
+		assert.Equal(t, i, seen[fmt.Sprint(i)])
+	}
+
+	calls := 0
+	s.Range(func(key string, value interface{}) bool {
+		calls++
+		return calls < 10
+	})
+	assert.Equal(t, 10, calls)
+
+	// modifications from within fn do not deadlock
+	s.Range(func(key string, value interface{}) bool {
+		assert.Equal(t, nil, s.Delete(key))
+		return true
+	})
+	assert.Equal(t, 0, s.Len())
+}
+
+// concurrentMap is implemented by both hash map variants
+type concurrentMap interface {
+	Upsert(key string, value interface{})
+	Value(key string) (interface{}, error)
+}
+
+// benchmarkParallel runs the same parallel workload on HashMap and Sharded.
+// Every goroutine writes once per the given number of operations and reads
+// otherwise. Lock contention only shows with more than one CPU, so the
+// benchmark is skipped if GOMAXPROCS is 1.
+func benchmarkParallel(b *testing.B, writeEvery int) {
+	if runtime.GOMAXPROCS(0) < 2 {
+		b.Skip("comparing lock contention needs GOMAXPROCS > 1")
+	}
+	const n = 1 << 16
+	keys := make([]string, n)
+	for i := range keys {
+		keys[i] = fmt.Sprint(i)
+	}
+	maps := []struct {
+		name string
+		m    concurrentMap
+	}{
+		{name: "HashMap", m: New(Options{})},
+		{name: "Sharded", m: NewSharded(0, Options{})},
+	}
+	for _, tc := range maps {
+		for _, key := range keys {
+			tc.m.Upsert(key, key)
+		}
+		b.Run(tc.name, func(b *testing.B) {
+			var goroutines int64
+			b.RunParallel(func(pb *testing.PB) {
+				// every goroutine walks the keys from a different offset
+				offset := int(atomic.AddInt64(&goroutines, 1)) * n / 16
+				for i := 0; pb.Next(); i++ {
+					key := keys[(offset+i*7919)%n]
+					if i%writeEvery == 0 {
+						tc.m.Upsert(key, i)
+					} else {
+						tc.m.Value(key)
+					}
+				}
+			})
+		})
+	}
+}
+
+func BenchmarkParallelUpsert(b *testing.B) {
+	benchmarkParallel(b, 1)
+}
+
+func BenchmarkParallelValue(b *testing.B) {
+	benchmarkParallel(b, 1<<30)
+}
+
+func BenchmarkParallelMixed(b *testing.B) {
+	benchmarkParallel(b, 10)
+}
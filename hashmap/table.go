@@ -0,0 +1,46 @@
+package hashmap
+
+// table is a hash table with separate chaining and a power of two number of
+// buckets. It is not safe for concurrent use.
+type table struct {
+	buckets [][]item
+}
+
+// newTable returns an empty table with the given number of buckets, which has
+// to be a power of two
+func newTable(size int) *table {
+	return &table{buckets: make([][]item, size)}
+}
+
+// index returns the index of the bucket for a hash sum
+func (t *table) index(sum uint64) int {
+	return int(sum & uint64(len(t.buckets)-1))
+}
+
+// find returns the position of a key in a bucket, or -1
+func (t *table) find(i int, key string) int {
+	for j := range t.buckets[i] {
+		if t.buckets[i][j].key == key {
+			return j
+		}
+	}
+	return -1
+}
+
+// remove deletes the item at position j from bucket i, keeping the order of
+// the remaining items
+func (t *table) remove(i, j int) {
+	b := t.buckets[i]
+	copy(b[j:], b[j+1:])
+	b[len(b)-1] = item{}
+	t.buckets[i] = b[:len(b)-1]
+}
+
+// move transfers all items of bucket i to another table
+func (t *table) move(i int, to *table) {
+	for _, it := range t.buckets[i] {
+		k := to.index(it.hash)
+		to.buckets[k] = append(to.buckets[k], it)
+	}
+	t.buckets[i] = nil
+}
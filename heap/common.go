@@ -1,4 +1,4 @@
-// Package heap implements a min and a max heap.
+// Package heap implements a min and a max heap as well as a min priority queue.
 package heap
 
 import "fmt"
@@ -0,0 +1,97 @@
+package heap
+
+import (
+	"sync"
+)
+
+// item is an entry of a priority queue. The sequence number breaks ties
+// between items of equal priority in insertion order.
+type item struct {
+	value    interface{}
+	priority float64
+	sequence uint64
+}
+
+// PriorityQueue represents a min priority queue instance. Items with the lowest
+// priority are popped first, items of equal priority are popped in the order
+// they have been inserted.
+type PriorityQueue struct {
+	lock     sync.RWMutex
+	data     []item
+	sequence uint64
+}
+
+// less reports whether the item at index i has to be popped before the item at
+// index j
+func (pq *PriorityQueue) less(i, j int) bool {
+	if pq.data[i].priority != pq.data[j].priority {
+		return pq.data[i].priority < pq.data[j].priority
+	}
+	return pq.data[i].sequence < pq.data[j].sequence
+}
+
+// Len returns the number of items in the queue
+func (pq *PriorityQueue) Len() int {
+	pq.lock.RLock()
+	defer pq.lock.RUnlock()
+	return len(pq.data)
+}
+
+// Peek returns the item with the lowest priority and its priority without
+// removing it
+func (pq *PriorityQueue) Peek() (interface{}, float64, error) {
+	pq.lock.RLock()
+	defer pq.lock.RUnlock()
+	if len(pq.data) == 0 {
+		return nil, 0, ErrorNoData
+	}
+	return pq.data[0].value, pq.data[0].priority, nil
+}
+
+// Insert adds an item with a priority to the queue
+func (pq *PriorityQueue) Insert(value interface{}, priority float64) {
+	pq.lock.Lock()
+	defer pq.lock.Unlock()
+	i := len(pq.data)
+	pq.data = append(pq.data, item{
+		value:    value,
+		priority: priority,
+		sequence: pq.sequence,
+	})
+	pq.sequence++
+	// heapify up
+	for ; i > 0 && pq.less(i, parent(i)); i = parent(i) {
+		pq.data[i], pq.data[parent(i)] = pq.data[parent(i)], pq.data[i]
+	}
+}
+
+// Pop removes the item with the lowest priority from the queue and returns it
+// along with its priority
+func (pq *PriorityQueue) Pop() (interface{}, float64, error) {
+	pq.lock.Lock()
+	defer pq.lock.Unlock()
+	if len(pq.data) == 0 {
+		return nil, 0, ErrorNoData
+	}
+	top := pq.data[0]
+	last := len(pq.data) - 1
+	pq.data[0] = pq.data[last]
+	pq.data[last] = item{}
+	pq.data = pq.data[:last]
+	// heapify down
+	for i := 0; ; {
+		smallest := i
+		if li := leftChild(i); li < len(pq.data) && pq.less(li, smallest) {
+			smallest = li
+		}
+		if ri := rightChild(i); ri < len(pq.data) && pq.less(ri, smallest) {
+			smallest = ri
+		}
+		if smallest == i {
+			break
+		}
+		pq.data[i], pq.data[smallest] = pq.data[smallest], pq.data[i]
+		i = smallest
+	}
+	return top.value, top.priority, nil
+}
@@ -0,0 +1,81 @@
+package heap
+
+import (
+	"testing"
+)
+
+func TestPriorityQueueLen(t *testing.T) {
+	pq := PriorityQueue{}
+	if got := pq.Len(); got != 0 {
+		t.Errorf("expected `%v`, got `%v`", 0, got)
+	}
+	pq.Insert("a", 1)
+	pq.Insert("b", 1)
+	if got := pq.Len(); got != 2 {
+		t.Errorf("expected `%v`, got `%v`", 2, got)
+	}
+}
+
+func TestPriorityQueuePeek(t *testing.T) {
+	t.Run("peek on empty queue", func(t *testing.T) {
+		pq := PriorityQueue{}
+		if _, _, err := pq.Peek(); err != ErrorNoData {
+			t.Errorf("expected `%v`, got `%v`", ErrorNoData, err)
+		}
+	})
+	t.Run("peek on queue with data", func(t *testing.T) {
+		pq := PriorityQueue{}
+		pq.Insert("b", 2.5)
+		pq.Insert("a", -1)
+		value, priority, err := pq.Peek()
+		if err != nil {
+			t.Errorf("expected `%v`, got `%v`", nil, err)
+		}
+		if value != "a" || priority != -1 {
+			t.Errorf("expected `%v (%v)`, got `%v (%v)`", "a", -1, value, priority)
+		}
+		if got := pq.Len(); got != 2 {
+			t.Errorf("expected `%v`, got `%v`", 2, got)
+		}
+	})
+}
+
+func TestPriorityQueuePop(t *testing.T) {
+	t.Run("pop from empty queue", func(t *testing.T) {
+		pq := PriorityQueue{}
+		if _, _, err := pq.Pop(); err != ErrorNoData {
+			t.Errorf("expected `%v`, got `%v`", ErrorNoData, err)
+		}
+	})
+	t.Run("pop in priority order", func(t *testing.T) {
+		pq := PriorityQueue{}
+		priorities := []float64{5, 3, 8, 1, 9, 2, 7, 4, 6, 0}
+		for _, p := range priorities {
+			pq.Insert(int(p), p)
+		}
+		for expected := 0; expected < len(priorities); expected++ {
+			value, priority, err := pq.Pop()
+			if err != nil {
+				t.Fatalf("expected `%v`, got `%v`", nil, err)
+			}
+			if value != expected || priority != float64(expected) {
+				t.Errorf("expected `%v`, got `%v`", expected, value)
+			}
+		}
+		if got := pq.Len(); got != 0 {
+			t.Errorf("expected `%v`, got `%v`", 0, got)
+		}
+	})
+	t.Run("equal priorities in insertion order", func(t *testing.T) {
+		pq := PriorityQueue{}
+		for _, value := range []string{"c", "a", "d", "b"} {
+			pq.Insert(value, 1)
+		}
+		pq.Insert("z", 0)
+		for _, expected := range []string{"z", "c", "a", "d", "b"} {
+			if value, _, _ := pq.Pop(); value != expected {
+				t.Errorf("expected `%v`, got `%v`", expected, value)
+			}
+		}
+	})
+}
@@ -0,0 +1,81 @@
+// Package dot implements a parser for a practical subset of the Graphviz DOT
+// language as well as helpers to write DOT documents. It is shared by the
+// graph packages and not meant to be used directly.
+//
+// Supported are the `strict`, `graph` and `digraph` keywords, node, edge and
+// attribute statements, `ID = ID` assignments, nested (anonymous) subgraphs
+// as well as edges to and from subgraphs, ports (which are ignored), all kinds
+// of identifiers, and C, C++ and preprocessor style comments.
+package dot
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ErrorSyntax is returned when the input is not a valid DOT document
+var ErrorSyntax = fmt.Errorf("syntax error")
+
+// Node is a node of a parsed graph with its attributes. Default attributes
+// set by `node [...]` statements are merged in.
+type Node struct {
+	ID    string
+	Attrs map[string]string
+}
+
+// Edge is an edge of a parsed graph with its attributes. Default attributes
+// set by `edge [...]` statements are merged in.
+type Edge struct {
+	From, To string
+	Attrs    map[string]string
+}
+
+// Graph is the result of parsing a DOT document. Nodes are listed in the order
+// of their first appearance, edges in the order of their appearance.
+type Graph struct {
+	Strict   bool
+	Directed bool
+	ID       string
+	Attrs    map[string]string
+	Nodes    []Node
+	Edges    []Edge
+}
+
+// Quote returns id as a double-quoted DOT string
+func Quote(id string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	return `"` + r.Replace(id) + `"`
+}
+
+// Attrs formats a list of attributes given as name-value pairs. Values are
+// quoted. An empty string is returned if no attributes are given.
+func Attrs(pairs ...string) string {
+	if len(pairs) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString(" [")
+	for i := 0; i+1 < len(pairs); i += 2 {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(pairs[i])
+		b.WriteString("=")
+		b.WriteString(Quote(pairs[i+1]))
+	}
+	b.WriteString("]")
+	return b.String()
+}
+
+// Parse reads a single graph in DOT format
+func Parse(r io.Reader) (*Graph, error) {
+	p := &parser{
+		lex:   newLexer(r),
+		index: make(map[string]int),
+	}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	return p.parse()
+}
@@ -0,0 +1,124 @@
+package dot
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestQuote(t *testing.T) {
+	tt := []struct {
+		in, expected string
+	}{
+		{in: "", expected: `""`},
+		{in: "foo", expected: `"foo"`},
+		{in: `say "hi"`, expected: `"say \"hi\""`},
+		{in: `back\slash`, expected: `"back\\slash"`},
+		{in: "two\nlines", expected: `"two\nlines"`},
+	}
+	for _, tc := range tt {
+		if got := Quote(tc.in); got != tc.expected {
+			t.Errorf("expected `%v` got `%v`", tc.expected, got)
+		}
+	}
+}
+
+func TestAttrs(t *testing.T) {
+	if got := Attrs(); got != "" {
+		t.Errorf("expected empty string, got `%v`", got)
+	}
+	expected := ` [label="foo", color="red"]`
+	if got := Attrs("label", "foo", "color", "red"); got != expected {
+		t.Errorf("expected `%v` got `%v`", expected, got)
+	}
+}
+
+func TestParse(t *testing.T) {
+	t.Run("directed graph", func(t *testing.T) {
+		in := `strict digraph "deps" {
+			rankdir = LR
+			node [shape=box]
+			a [label="first"]
+			a -> b -> c [weight=2];
+			node [shape=circle]
+			c:n -> d:sw
+			edge [color=red]
+			d -> { e f }
+			graph [bgcolor=white]
+		}`
+		g, err := Parse(strings.NewReader(in))
+		if err != nil {
+			t.Fatalf("expected `%v` got `%v`", nil, err)
+		}
+		if !g.Strict || !g.Directed || g.ID != "deps" {
+			t.Errorf("unexpected graph header: %v %v %v", g.Strict, g.Directed, g.ID)
+		}
+		if g.Attrs["rankdir"] != "LR" || g.Attrs["bgcolor"] != "white" {
+			t.Errorf("unexpected graph attributes: %v", g.Attrs)
+		}
+		ids := []string{"a", "b", "c", "d", "e", "f"}
+		if len(g.Nodes) != len(ids) {
+			t.Fatalf("expected `%v` nodes, got `%v`", len(ids), len(g.Nodes))
+		}
+		for i, id := range ids {
+			if g.Nodes[i].ID != id {
+				t.Errorf("expected node `%v` got `%v`", id, g.Nodes[i].ID)
+			}
+		}
+		if g.Nodes[0].Attrs["label"] != "first" {
+			t.Errorf("expected label `first` got `%v`", g.Nodes[0].Attrs["label"])
+		}
+		if g.Nodes[2].Attrs["shape"] != "box" || g.Nodes[3].Attrs["shape"] != "circle" {
+			t.Errorf("default node attributes not applied")
+		}
+		edges := [][2]string{{"a", "b"}, {"b", "c"}, {"c", "d"}, {"d", "e"},
+			{"d", "f"}}
+		if len(g.Edges) != len(edges) {
+			t.Fatalf("expected `%v` edges, got `%v`", len(edges), len(g.Edges))
+		}
+		for i, e := range edges {
+			if g.Edges[i].From != e[0] || g.Edges[i].To != e[1] {
+				t.Errorf("expected edge `%v`->`%v` got `%v`->`%v`", e[0], e[1],
+					g.Edges[i].From, g.Edges[i].To)
+			}
+		}
+		if g.Edges[1].Attrs["weight"] != "2" {
+			t.Errorf("edge attributes not applied")
+		}
+		if g.Edges[3].Attrs["color"] != "red" || g.Edges[2].Attrs["color"] != "" {
+			t.Errorf("default edge attributes not applied correctly")
+		}
+	})
+	t.Run("undirected graph", func(t *testing.T) {
+		in := `graph { subgraph cluster { x; y } -- z; z -- z }`
+		g, err := Parse(strings.NewReader(in))
+		if err != nil {
+			t.Fatalf("expected `%v` got `%v`", nil, err)
+		}
+		if g.Directed || g.Strict {
+			t.Errorf("unexpected graph header")
+		}
+		if len(g.Nodes) != 3 || len(g.Edges) != 3 {
+			t.Errorf("expected 3 nodes and 3 edges, got `%v` and `%v`",
+				len(g.Nodes), len(g.Edges))
+		}
+	})
+	t.Run("invalid documents", func(t *testing.T) {
+		for _, in := range []string{
+			``,
+			`tree {}`,
+			`graph { a -> b }`,
+			`digraph { a -- b }`,
+			`digraph { a -> }`,
+			`digraph { a [label] }`,
+			`digraph { a `,
+			`digraph { } trailing`,
+			`digraph { node }`,
+		} {
+			_, err := Parse(strings.NewReader(in))
+			if !errors.Is(err, ErrorSyntax) {
+				t.Errorf("input `%v`: expected `%v` got `%v`", in, ErrorSyntax, err)
+			}
+		}
+	})
+}
@@ -0,0 +1,300 @@
+package dot
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+)
+
+// tokenKind classifies the tokens of the DOT language
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenID
+	tokenEdgeOp
+	tokenPunct
+)
+
+// token is a lexical unit of the DOT language. For quoted strings `quoted` is
+// set and `text` holds the unescaped content.
+type token struct {
+	kind   tokenKind
+	text   string
+	quoted bool
+	line   int
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokenEOF:
+		return "end of input"
+	case tokenID:
+		if t.quoted {
+			return fmt.Sprintf("%q", t.text)
+		}
+	}
+	return fmt.Sprintf("`%s`", t.text)
+}
+
+// lexer splits a DOT document into tokens, skipping whitespace, comments and
+// preprocessor output lines
+type lexer struct {
+	in   *bufio.Reader
+	line int
+	bol  bool
+}
+
+func newLexer(r io.Reader) *lexer {
+	return &lexer{
+		in:   bufio.NewReader(r),
+		line: 1,
+		bol:  true,
+	}
+}
+
+func (l *lexer) errorf(format string, a ...interface{}) error {
+	return fmt.Errorf("%w: line %d: %s", ErrorSyntax, l.line,
+		fmt.Sprintf(format, a...))
+}
+
+// read returns the next rune and keeps track of line numbers
+func (l *lexer) read() (rune, error) {
+	r, _, err := l.in.ReadRune()
+	if err != nil {
+		return 0, err
+	}
+	if r == '\n' {
+		l.line++
+	}
+	return r, nil
+}
+
+func (l *lexer) unread(r rune) {
+	l.in.UnreadRune()
+	if r == '\n' {
+		l.line--
+	}
+}
+
+func (l *lexer) peek() rune {
+	r, err := l.read()
+	if err != nil {
+		return 0
+	}
+	l.unread(r)
+	return r
+}
+
+// skipLine discards everything up to and including the next line break
+func (l *lexer) skipLine() error {
+	for {
+		r, err := l.read()
+		if err != nil || r == '\n' {
+			return err
+		}
+	}
+}
+
+// skipBlock discards everything up to and including the end of a C-style
+// block comment
+func (l *lexer) skipBlock() error {
+	star := false
+	for {
+		r, err := l.read()
+		if err == io.EOF {
+			return l.errorf("unterminated comment")
+		}
+		if err != nil {
+			return err
+		}
+		if star && r == '/' {
+			return nil
+		}
+		star = r == '*'
+	}
+}
+
+// next returns the next token from the input
+func (l *lexer) next() (token, error) {
+	for {
+		r, err := l.read()
+		if err == io.EOF {
+			return token{kind: tokenEOF, line: l.line}, nil
+		}
+		if err != nil {
+			return token{}, err
+		}
+		bol := l.bol
+		l.bol = r == '\n'
+		switch {
+		case unicode.IsSpace(r):
+			if bol {
+				l.bol = true
+			}
+			continue
+		case r == '#' && bol:
+			// lines starting with '#' are treated as preprocessor output
+			if err := l.skipLine(); err != nil && err != io.EOF {
+				return token{}, err
+			}
+			l.bol = true
+			continue
+		case r == '/' && l.peek() == '/':
+			if err := l.skipLine(); err != nil && err != io.EOF {
+				return token{}, err
+			}
+			l.bol = true
+			continue
+		case r == '/' && l.peek() == '*':
+			l.read()
+			if err := l.skipBlock(); err != nil {
+				return token{}, err
+			}
+			continue
+		case r == '-' && (l.peek() == '>' || l.peek() == '-'):
+			op, _ := l.read()
+			return token{kind: tokenEdgeOp, text: string([]rune{r, op}),
+				line: l.line}, nil
+		case strings.ContainsRune("{}[]=;,:", r):
+			return token{kind: tokenPunct, text: string(r), line: l.line}, nil
+		case r == '"':
+			return l.quoted()
+		case r == '<':
+			return l.html()
+		case r == '-' || r == '.' || unicode.IsDigit(r):
+			return l.numeral(r)
+		case r == '_' || unicode.IsLetter(r):
+			l.unread(r)
+			return l.identifier()
+		}
+		return token{}, l.errorf("unexpected character %q", r)
+	}
+}
+
+// quoted reads a double-quoted string. Escaped quotes and backslashes are
+// unescaped, all other escape sequences are preserved as is. Lines may be
+// continued with a backslash-newline sequence.
+func (l *lexer) quoted() (token, error) {
+	var b strings.Builder
+	line := l.line
+	for {
+		r, err := l.read()
+		if err == io.EOF {
+			return token{}, l.errorf("unterminated string")
+		}
+		if err != nil {
+			return token{}, err
+		}
+		switch r {
+		case '"':
+			return token{kind: tokenID, text: b.String(), quoted: true,
+				line: line}, nil
+		case '\\':
+			e, err := l.read()
+			if err == io.EOF {
+				return token{}, l.errorf("unterminated string")
+			}
+			if err != nil {
+				return token{}, err
+			}
+			switch e {
+			case '"', '\\':
+				b.WriteRune(e)
+			case '\n':
+			default:
+				b.WriteRune(r)
+				b.WriteRune(e)
+			}
+		default:
+			b.WriteRune(r)
+		}
+	}
+}
+
+// html reads an HTML string delimited by balanced angle brackets
+func (l *lexer) html() (token, error) {
+	var b strings.Builder
+	line := l.line
+	depth := 1
+	for {
+		r, err := l.read()
+		if err == io.EOF {
+			return token{}, l.errorf("unterminated HTML string")
+		}
+		if err != nil {
+			return token{}, err
+		}
+		switch r {
+		case '<':
+			depth++
+		case '>':
+			depth--
+			if depth == 0 {
+				return token{kind: tokenID, text: b.String(), quoted: true,
+					line: line}, nil
+			}
+		}
+		b.WriteRune(r)
+	}
+}
+
+// numeral reads a number of the form [-]?(.[0-9]+|[0-9]+(.[0-9]*)?) starting
+// with the already consumed rune first
+func (l *lexer) numeral(first rune) (token, error) {
+	var b strings.Builder
+	dot := first == '.'
+	digits := 0
+	if unicode.IsDigit(first) {
+		digits++
+	}
+	b.WriteRune(first)
+	for {
+		r, err := l.read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return token{}, err
+		}
+		if r == '.' && !dot {
+			dot = true
+			b.WriteRune(r)
+			continue
+		}
+		if unicode.IsDigit(r) {
+			digits++
+			b.WriteRune(r)
+			continue
+		}
+		l.unread(r)
+		break
+	}
+	if digits == 0 {
+		return token{}, l.errorf("invalid numeral `%s`", b.String())
+	}
+	return token{kind: tokenID, text: b.String(), line: l.line}, nil
+}
+
+// identifier reads a string of alphabetic characters, underscores and digits
+// not beginning with a digit
+func (l *lexer) identifier() (token, error) {
+	var b strings.Builder
+	for {
+		r, err := l.read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return token{}, err
+		}
+		if r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			l.unread(r)
+			break
+		}
+		b.WriteRune(r)
+	}
+	return token{kind: tokenID, text: b.String(), line: l.line}, nil
+}
@@ -0,0 +1,64 @@
+package dot
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestLexer(t *testing.T) {
+	t.Run("tokens", func(t *testing.T) {
+		in := `digraph G { // comment
+# preprocessor line
+	a1 -> "b \"c\"\\d\l" -- -1.5 /* block
+comment */ .5 [x=<<b>html</b>>]; }`
+		expected := []token{
+			{kind: tokenID, text: "digraph"},
+			{kind: tokenID, text: "G"},
+			{kind: tokenPunct, text: "{"},
+			{kind: tokenID, text: "a1"},
+			{kind: tokenEdgeOp, text: "->"},
+			{kind: tokenID, text: `b "c"\d\l`, quoted: true},
+			{kind: tokenEdgeOp, text: "--"},
+			{kind: tokenID, text: "-1.5"},
+			{kind: tokenID, text: ".5"},
+			{kind: tokenPunct, text: "["},
+			{kind: tokenID, text: "x"},
+			{kind: tokenPunct, text: "="},
+			{kind: tokenID, text: "<b>html</b>", quoted: true},
+			{kind: tokenPunct, text: "]"},
+			{kind: tokenPunct, text: ";"},
+			{kind: tokenPunct, text: "}"},
+			{kind: tokenEOF},
+		}
+		l := newLexer(strings.NewReader(in))
+		for _, e := range expected {
+			got, err := l.next()
+			if err != nil {
+				t.Fatalf("expected `%v` got `%v`", nil, err)
+			}
+			if got.kind != e.kind || got.text != e.text || got.quoted != e.quoted {
+				t.Errorf("expected `%v` got `%v`", e, got)
+			}
+		}
+	})
+	t.Run("line numbers", func(t *testing.T) {
+		l := newLexer(strings.NewReader("a\n\n\"b\nc\"\n/* \n */ d"))
+		for _, line := range []int{1, 3, 6} {
+			got, _ := l.next()
+			if got.line != line {
+				t.Errorf("token %v: expected line `%v` got `%v`", got, line, got.line)
+			}
+		}
+	})
+	t.Run("invalid input", func(t *testing.T) {
+		for _, in := range []string{`"open`, `<open`, `/* open`, `@`, `-`,
+			`/`} {
+			l := newLexer(strings.NewReader(in))
+			_, err := l.next()
+			if !errors.Is(err, ErrorSyntax) {
+				t.Errorf("input `%v`: expected `%v` got `%v`", in, ErrorSyntax, err)
+			}
+		}
+	})
+}
@@ -0,0 +1,344 @@
+package dot
+
+import "strings"
+
+// scope holds the default attributes for nodes and edges. Subgraphs inherit a
+// copy of the scope they are defined in.
+type scope struct {
+	node map[string]string
+	edge map[string]string
+}
+
+func (s scope) copy() scope {
+	return scope{
+		node: merge(nil, s.node),
+		edge: merge(nil, s.edge),
+	}
+}
+
+// merge copies all attributes from src to dst, allocating dst if necessary
+func merge(dst, src map[string]string) map[string]string {
+	if dst == nil {
+		dst = make(map[string]string, len(src))
+	}
+	for k, v := range src {
+		dst[k] = v
+	}
+	return dst
+}
+
+// parser is a recursive descent parser for the DOT grammar
+type parser struct {
+	lex   *lexer
+	tok   token
+	graph Graph
+	index map[string]int
+}
+
+func (p *parser) advance() error {
+	tok, err := p.lex.next()
+	if err != nil {
+		return err
+	}
+	p.tok = tok
+	return nil
+}
+
+func (p *parser) unexpected() error {
+	p.lex.line = p.tok.line
+	return p.lex.errorf("unexpected %v", p.tok)
+}
+
+// keyword tests if the current token is the given (case-insensitive) keyword
+func (p *parser) keyword(kw string) bool {
+	return p.tok.kind == tokenID && !p.tok.quoted &&
+		strings.EqualFold(p.tok.text, kw)
+}
+
+// punct tests if the current token is the given punctuation character
+func (p *parser) punct(c string) bool {
+	return p.tok.kind == tokenPunct && p.tok.text == c
+}
+
+// expect consumes the given punctuation character or fails
+func (p *parser) expect(c string) error {
+	if !p.punct(c) {
+		return p.unexpected()
+	}
+	return p.advance()
+}
+
+// id consumes an identifier and returns its text
+func (p *parser) id() (string, error) {
+	if p.tok.kind != tokenID {
+		return "", p.unexpected()
+	}
+	text := p.tok.text
+	return text, p.advance()
+}
+
+// node registers a node with the default attributes of the current scope, if
+// it does not exist yet, and merges the given attributes into it
+func (p *parser) node(id string, s scope, attrs map[string]string) {
+	i, ok := p.index[id]
+	if !ok {
+		i = len(p.graph.Nodes)
+		p.index[id] = i
+		p.graph.Nodes = append(p.graph.Nodes, Node{
+			ID:    id,
+			Attrs: merge(nil, s.node),
+		})
+	}
+	merge(p.graph.Nodes[i].Attrs, attrs)
+}
+
+// parse parses: [ strict ] ( graph | digraph ) [ ID ] '{' stmt_list '}'
+func (p *parser) parse() (*Graph, error) {
+	if p.keyword("strict") {
+		p.graph.Strict = true
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+	}
+	switch {
+	case p.keyword("graph"):
+	case p.keyword("digraph"):
+		p.graph.Directed = true
+	default:
+		return nil, p.unexpected()
+	}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	if p.tok.kind == tokenID {
+		p.graph.ID = p.tok.text
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+	}
+	p.graph.Attrs = make(map[string]string)
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+	s := scope{
+		node: make(map[string]string),
+		edge: make(map[string]string),
+	}
+	if _, err := p.stmtList(s); err != nil {
+		return nil, err
+	}
+	if err := p.expect("}"); err != nil {
+		return nil, err
+	}
+	if p.tok.kind != tokenEOF {
+		return nil, p.unexpected()
+	}
+	return &p.graph, nil
+}
+
+// stmtList parses statements up to the closing brace and returns the IDs of
+// all nodes mentioned
+func (p *parser) stmtList(s scope) ([]string, error) {
+	var ids []string
+	for !p.punct("}") {
+		if p.tok.kind == tokenEOF {
+			return nil, p.unexpected()
+		}
+		stmtIDs, err := p.stmt(s)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, stmtIDs...)
+		if p.punct(";") {
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return ids, nil
+}
+
+// stmt parses a single statement and returns the IDs of all nodes mentioned
+func (p *parser) stmt(s scope) ([]string, error) {
+	switch {
+	case p.keyword("graph"), p.keyword("node"), p.keyword("edge"):
+		kind := strings.ToLower(p.tok.text)
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		attrs, err := p.attrList()
+		if err != nil {
+			return nil, err
+		}
+		switch kind {
+		case "graph":
+			merge(p.graph.Attrs, attrs)
+		case "node":
+			merge(s.node, attrs)
+		case "edge":
+			merge(s.edge, attrs)
+		}
+		return nil, nil
+	}
+
+	left, err := p.operand(s)
+	if err != nil {
+		return nil, err
+	}
+	// ID '=' ID
+	if p.punct("=") && !left.subgraph {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		value, err := p.id()
+		if err != nil {
+			return nil, err
+		}
+		p.graph.Attrs[left.ids[0]] = value
+		return nil, nil
+	}
+	// node statement
+	if p.tok.kind != tokenEdgeOp {
+		if left.subgraph {
+			return left.ids, nil
+		}
+		attrs, err := p.optAttrList()
+		if err != nil {
+			return nil, err
+		}
+		p.node(left.ids[0], s, attrs)
+		return left.ids, nil
+	}
+	// edge statement
+	operands := []operand{left}
+	ids := append([]string{}, left.ids...)
+	for p.tok.kind == tokenEdgeOp {
+		if (p.tok.text == "->") != p.graph.Directed {
+			return nil, p.unexpected()
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		right, err := p.operand(s)
+		if err != nil {
+			return nil, err
+		}
+		operands = append(operands, right)
+		ids = append(ids, right.ids...)
+	}
+	attrs, err := p.optAttrList()
+	if err != nil {
+		return nil, err
+	}
+	for _, o := range operands {
+		if !o.subgraph {
+			p.node(o.ids[0], s, nil)
+		}
+	}
+	for i := 0; i+1 < len(operands); i++ {
+		for _, from := range operands[i].ids {
+			for _, to := range operands[i+1].ids {
+				p.graph.Edges = append(p.graph.Edges, Edge{
+					From:  from,
+					To:    to,
+					Attrs: merge(merge(nil, s.edge), attrs),
+				})
+			}
+		}
+	}
+	return ids, nil
+}
+
+// operand is either a single node ID or the list of nodes of a subgraph
+type operand struct {
+	ids      []string
+	subgraph bool
+}
+
+// operand parses a node ID with optional port or a subgraph
+func (p *parser) operand(s scope) (operand, error) {
+	if p.keyword("subgraph") || p.punct("{") {
+		ids, err := p.subgraph(s)
+		return operand{ids: ids, subgraph: true}, err
+	}
+	id, err := p.id()
+	if err != nil {
+		return operand{}, err
+	}
+	// ports are accepted but ignored
+	for i := 0; i < 2 && p.punct(":"); i++ {
+		if err := p.advance(); err != nil {
+			return operand{}, err
+		}
+		if _, err := p.id(); err != nil {
+			return operand{}, err
+		}
+	}
+	return operand{ids: []string{id}}, nil
+}
+
+// subgraph parses: [ subgraph [ ID ] ] '{' stmt_list '}'
+func (p *parser) subgraph(s scope) ([]string, error) {
+	if p.keyword("subgraph") {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		if p.tok.kind == tokenID {
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+	ids, err := p.stmtList(s.copy())
+	if err != nil {
+		return nil, err
+	}
+	return ids, p.expect("}")
+}
+
+// optAttrList parses an attribute list if there is one
+func (p *parser) optAttrList() (map[string]string, error) {
+	if !p.punct("[") {
+		return nil, nil
+	}
+	return p.attrList()
+}
+
+// attrList parses: ( '[' [ a_list ] ']' )+
+func (p *parser) attrList() (map[string]string, error) {
+	attrs := make(map[string]string)
+	if !p.punct("[") {
+		return nil, p.unexpected()
+	}
+	for p.punct("[") {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		for !p.punct("]") {
+			name, err := p.id()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect("="); err != nil {
+				return nil, err
+			}
+			value, err := p.id()
+			if err != nil {
+				return nil, err
+			}
+			attrs[name] = value
+			if p.punct(";") || p.punct(",") {
+				if err := p.advance(); err != nil {
+					return nil, err
+				}
+			}
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+	}
+	return attrs, nil
+}
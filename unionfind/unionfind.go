@@ -0,0 +1,126 @@
+// Package unionfind implements a disjoint-set data structure, also known as
+// union-find, with path compression and union by rank.
+package unionfind
+
+import (
+	"fmt"
+	"sync"
+)
+
+var (
+	// ErrorNotFound is returned when trying to access a non-existent element
+	ErrorNotFound = fmt.Errorf("not found")
+	// ErrorAlreadyExists is returned when trying to add duplicate elements
+	ErrorAlreadyExists = fmt.Errorf("already exists")
+)
+
+// UnionFind holds a concurrency-safe collection of disjoint sets of string
+// keys. The zero value is an empty collection ready to use.
+type UnionFind struct {
+	lock   sync.Mutex
+	parent map[string]string
+	rank   map[string]int
+	sets   int
+}
+
+// Add adds a new element as a set of its own
+func (u *UnionFind) Add(key string) error {
+	u.lock.Lock()
+	defer u.lock.Unlock()
+
+	if u.parent == nil {
+		u.parent = make(map[string]string)
+		u.rank = make(map[string]int)
+	}
+	if _, ok := u.parent[key]; ok {
+		return ErrorAlreadyExists
+	}
+	u.parent[key] = key
+	u.sets++
+	return nil
+}
+
+// find returns the representative of the set containing key. All elements on
+// the way to the representative are linked to it directly afterwards.
+func (u *UnionFind) find(key string) string {
+	root := key
+	for u.parent[root] != root {
+		root = u.parent[root]
+	}
+	for key != root {
+		next := u.parent[key]
+		u.parent[key] = root
+		key = next
+	}
+	return root
+}
+
+// Find returns the representative element of the set containing key. Two
+// elements are in the same set if they have the same representative.
+func (u *UnionFind) Find(key string) (string, error) {
+	u.lock.Lock()
+	defer u.lock.Unlock()
+
+	if _, ok := u.parent[key]; !ok {
+		return "", ErrorNotFound
+	}
+	return u.find(key), nil
+}
+
+// Union merges the sets containing a and b. It returns true if the sets have
+// been merged and false if both elements have already been in the same set.
+func (u *UnionFind) Union(a, b string) (bool, error) {
+	u.lock.Lock()
+	defer u.lock.Unlock()
+
+	if _, ok := u.parent[a]; !ok {
+		return false, ErrorNotFound
+	}
+	if _, ok := u.parent[b]; !ok {
+		return false, ErrorNotFound
+	}
+	ra, rb := u.find(a), u.find(b)
+	if ra == rb {
+		return false, nil
+	}
+	// attach the shallower tree to the deeper one
+	switch {
+	case u.rank[ra] < u.rank[rb]:
+		u.parent[ra] = rb
+	case u.rank[ra] > u.rank[rb]:
+		u.parent[rb] = ra
+	default:
+		u.parent[rb] = ra
+		u.rank[ra]++
+	}
+	u.sets--
+	return true, nil
+}
+
+// Connected returns true if a and b are in the same set
+func (u *UnionFind) Connected(a, b string) (bool, error) {
+	u.lock.Lock()
+	defer u.lock.Unlock()
+
+	if _, ok := u.parent[a]; !ok {
+		return false, ErrorNotFound
+	}
+	if _, ok := u.parent[b]; !ok {
+		return false, ErrorNotFound
+	}
+	return u.find(a) == u.find(b), nil
+}
+
+// Len returns the number of elements
+func (u *UnionFind) Len() int {
+	u.lock.Lock()
+	defer u.lock.Unlock()
+	return len(u.parent)
+}
+
+// Sets returns the number of disjoint sets
+func (u *UnionFind) Sets() int {
+	u.lock.Lock()
+	defer u.lock.Unlock()
+	return u.sets
+}
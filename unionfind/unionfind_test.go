@@ -0,0 +1,154 @@
+package unionfind
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestAdd(t *testing.T) {
+	t.Run("new elements", func(t *testing.T) {
+		u := UnionFind{}
+		for _, key := range []string{"a", "b", "c"} {
+			if err := u.Add(key); err != nil {
+				t.Errorf("expected `%v` got `%v`", nil, err)
+			}
+		}
+		if got := u.Len(); got != 3 {
+			t.Errorf("expected `%v` got `%v`", 3, got)
+		}
+		if got := u.Sets(); got != 3 {
+			t.Errorf("expected `%v` got `%v`", 3, got)
+		}
+	})
+	t.Run("duplicate element", func(t *testing.T) {
+		u := UnionFind{}
+		u.Add("a")
+		if err := u.Add("a"); err != ErrorAlreadyExists {
+			t.Errorf("expected `%v` got `%v`", ErrorAlreadyExists, err)
+		}
+		if got := u.Sets(); got != 1 {
+			t.Errorf("expected `%v` got `%v`", 1, got)
+		}
+	})
+}
+
+func TestFind(t *testing.T) {
+	t.Run("single element", func(t *testing.T) {
+		u := UnionFind{}
+		u.Add("a")
+		got, err := u.Find("a")
+		if err != nil {
+			t.Errorf("expected `%v` got `%v`", nil, err)
+		}
+		if got != "a" {
+			t.Errorf("expected `%v` got `%v`", "a", got)
+		}
+	})
+	t.Run("path compression", func(t *testing.T) {
+		u := UnionFind{}
+		for _, key := range []string{"a", "b", "c", "d"} {
+			u.Add(key)
+		}
+		// build a chain manually to test compression
+		u.parent["b"] = "a"
+		u.parent["c"] = "b"
+		u.parent["d"] = "c"
+		got, _ := u.Find("d")
+		if got != "a" {
+			t.Errorf("expected `%v` got `%v`", "a", got)
+		}
+		for _, key := range []string{"b", "c", "d"} {
+			if u.parent[key] != "a" {
+				t.Errorf("node `%v`: expected parent `%v` got `%v`", key, "a",
+					u.parent[key])
+			}
+		}
+	})
+	t.Run("unknown element", func(t *testing.T) {
+		u := UnionFind{}
+		if _, err := u.Find("a"); err != ErrorNotFound {
+			t.Errorf("expected `%v` got `%v`", ErrorNotFound, err)
+		}
+	})
+}
+
+func TestUnion(t *testing.T) {
+	t.Run("merge sets", func(t *testing.T) {
+		u := UnionFind{}
+		for _, key := range []string{"a", "b", "c", "d"} {
+			u.Add(key)
+		}
+		if merged, _ := u.Union("a", "b"); !merged {
+			t.Errorf("expected `%v` got `%v`", true, merged)
+		}
+		if merged, _ := u.Union("c", "d"); !merged {
+			t.Errorf("expected `%v` got `%v`", true, merged)
+		}
+		if merged, _ := u.Union("b", "a"); merged {
+			t.Errorf("expected `%v` got `%v`", false, merged)
+		}
+		if got := u.Sets(); got != 2 {
+			t.Errorf("expected `%v` got `%v`", 2, got)
+		}
+		u.Union("a", "d")
+		if got := u.Sets(); got != 1 {
+			t.Errorf("expected `%v` got `%v`", 1, got)
+		}
+	})
+	t.Run("union by rank", func(t *testing.T) {
+		u := UnionFind{}
+		for i := 0; i < 1024; i++ {
+			u.Add(strconv.Itoa(i))
+		}
+		// merging sets of equal size doubles the set size per round
+		for step := 1; step < 1024; step *= 2 {
+			for i := 0; i < 1024; i += 2 * step {
+				u.Union(strconv.Itoa(i), strconv.Itoa(i+step))
+			}
+		}
+		for key, rank := range u.rank {
+			if rank > 10 {
+				t.Errorf("element `%v`: rank `%v` exceeds `%v`", key, rank, 10)
+			}
+		}
+		if got := u.Sets(); got != 1 {
+			t.Errorf("expected `%v` got `%v`", 1, got)
+		}
+	})
+	t.Run("unknown elements", func(t *testing.T) {
+		u := UnionFind{}
+		u.Add("a")
+		if _, err := u.Union("a", "b"); err != ErrorNotFound {
+			t.Errorf("expected `%v` got `%v`", ErrorNotFound, err)
+		}
+		if _, err := u.Union("b", "a"); err != ErrorNotFound {
+			t.Errorf("expected `%v` got `%v`", ErrorNotFound, err)
+		}
+	})
+}
+
+func TestConnected(t *testing.T) {
+	t.Run("existing elements", func(t *testing.T) {
+		u := UnionFind{}
+		for _, key := range []string{"a", "b", "c"} {
+			u.Add(key)
+		}
+		u.Union("a", "b")
+		if got, _ := u.Connected("b", "a"); !got {
+			t.Errorf("expected `%v` got `%v`", true, got)
+		}
+		if got, _ := u.Connected("a", "c"); got {
+			t.Errorf("expected `%v` got `%v`", false, got)
+		}
+	})
+	t.Run("unknown elements", func(t *testing.T) {
+		u := UnionFind{}
+		u.Add("a")
+		if _, err := u.Connected("a", "b"); err != ErrorNotFound {
+			t.Errorf("expected `%v` got `%v`", ErrorNotFound, err)
+		}
+		if _, err := u.Connected("b", "a"); err != ErrorNotFound {
+			t.Errorf("expected `%v` got `%v`", ErrorNotFound, err)
+		}
+	})
+}